@@ -0,0 +1,97 @@
+// Package bots contiene las estrategias que juegan sin humano, su registro por
+// nombre y las pistas que se le dan al jugador humano.
+package bots
+
+import (
+	"time"
+
+	"com.github/hapkiduki/rummikub/mazo"
+	"com.github/hapkiduki/rummikub/motor"
+	"com.github/hapkiduki/rummikub/reglas"
+)
+
+// --- ESTRATEGIA: BOT NOVATO
+
+// EstrategiaNovato baja la jugada más larga que encuentra en la mano y, si no
+// tiene ninguna, roba.
+type EstrategiaNovato struct {
+	Silencioso bool // Sin pausas, para simulaciones.
+}
+
+// Piensa indica si el bot hace una pausa en cada turno (ver motor.EstrategiaPensativa).
+func (e EstrategiaNovato) Piensa() bool { return !e.Silencioso }
+
+// JugarTurno baja una jugada de la mano si puede.
+func (e EstrategiaNovato) JugarTurno(jugador *motor.Jugador, mesa [][]mazo.Pieza) [][]mazo.Pieza {
+	pensar(e.Silencioso)
+	result := <-reglas.BuscarJugadaEnMano(jugador.Mano)
+	jugadaEncontrada := result.Jugada
+	if jugadaEncontrada != nil && !jugador.HaHechoPrimeraJugada {
+		if reglas.CalcularValorJugada(jugadaEncontrada) < 30 {
+			jugadaEncontrada = nil // La jugada no es válida para abrir.
+		} else {
+			jugador.HaHechoPrimeraJugada = true
+		}
+	}
+	if jugadaEncontrada != nil {
+		mesa = append(mesa, jugadaEncontrada)
+		jugador.Mano = reglas.QuitarFichas(jugador.Mano, jugadaEncontrada)
+	}
+	return mesa
+}
+
+// --- ESTRATEGIA: BOT INTERMEDIO
+
+// EstrategiaIntermedio juega como EstrategiaNovato y, si no tiene jugada en la
+// mano, intenta añadir una ficha a alguna jugada de la mesa.
+type EstrategiaIntermedio struct {
+	Silencioso bool                // Sin pausas, para simulaciones.
+	Grupos     reglas.ReglasGrupos // Variantes de la partida para saber qué tríos admiten fichas.
+}
+
+// Piensa indica si el bot hace una pausa en cada turno (ver motor.EstrategiaPensativa).
+func (e EstrategiaIntermedio) Piensa() bool { return !e.Silencioso }
+
+// JugarTurno baja una jugada de la mano o, si no puede, añade una ficha a la mesa.
+func (e EstrategiaIntermedio) JugarTurno(jugador *motor.Jugador, mesa [][]mazo.Pieza) [][]mazo.Pieza {
+	pensar(e.Silencioso)
+	// Intenta jugar como un Novato primero (bajar un grupo nuevo)
+	result := <-reglas.BuscarJugadaEnMano(jugador.Mano)
+	jugadaEncontrada := result.Jugada
+	if jugadaEncontrada != nil && !jugador.HaHechoPrimeraJugada {
+		if reglas.CalcularValorJugada(jugadaEncontrada) < 30 {
+			jugadaEncontrada = nil // La jugada no es válida para abrir.
+		} else {
+			jugador.HaHechoPrimeraJugada = true
+		}
+	}
+	if jugadaEncontrada != nil {
+		mesa = append(mesa, jugadaEncontrada)
+		jugador.Mano = reglas.QuitarFichas(jugador.Mano, jugadaEncontrada)
+		return mesa
+	}
+	// SI no puedo intenta añadir una ficha a la mesa
+	if jugador.HaHechoPrimeraJugada { // Solo puede añadir si ya abrió.
+		for i, ficha := range jugador.Mano {
+			for j, jugadaEnMesa := range mesa {
+				if e.Grupos.SePuedeAnadirFicha(jugadaEnMesa, ficha) {
+					mesa[j] = append(mesa[j], ficha)
+					reglas.OrdenarJugada(mesa[j])
+					jugador.Mano = reglas.QuitarFichasDeMano(jugador.Mano, map[int]bool{i: true})
+					return mesa
+				}
+			}
+		}
+	}
+	// Si no pudo hacer nada, la partida le hará robar.
+	return mesa
+}
+
+// pensar hace una pausa para que el humano pueda seguir la partida, salvo en silencio.
+// La partida ya ha anunciado con JugadorPensando que el bot está pensando.
+func pensar(silencioso bool) {
+	if silencioso {
+		return
+	}
+	time.Sleep(3 * time.Second)
+}
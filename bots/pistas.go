@@ -0,0 +1,147 @@
+package bots
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"com.github/hapkiduki/rummikub/idioma"
+	"com.github/hapkiduki/rummikub/mazo"
+	"com.github/hapkiduki/rummikub/motor"
+	"com.github/hapkiduki/rummikub/reglas"
+)
+
+// --- PISTAS PARA EL JUGADOR HUMANO ---
+
+// NivelPista indica cuánta información da una pista.
+type NivelPista int
+
+const (
+	PistasDesactivadas NivelPista = iota // El comando hint no da pistas.
+	PistaBasica                          // Dice qué se puede hacer, sin decir con qué fichas.
+	PistaCompleta                        // Señala las fichas y la jugada concreta.
+)
+
+// ParsearNivelPista convierte el valor del flag -pistas en un NivelPista.
+func ParsearNivelPista(s string) (NivelPista, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "no", "ninguna", "desactivadas":
+		return PistasDesactivadas, nil
+	case "basica", "básica":
+		return PistaBasica, nil
+	case "completa":
+		return PistaCompleta, nil
+	}
+	return PistasDesactivadas, fmt.Errorf(idioma.T("nivel de pistas desconocido '%s' (usa no, basica o completa)"), s)
+}
+
+// DarPista sugiere al jugador qué hacer con su mano y la mesa actual, con las
+// variantes de grupos de la partida.
+func DarPista(jugador *motor.Jugador, mesa [][]mazo.Pieza, grupos reglas.ReglasGrupos, nivel NivelPista) string {
+	jugadas := grupos.JugadasPosibles(jugador.Mano)
+	if !jugador.HaHechoPrimeraJugada {
+		apertura, puntos := mejorApertura(jugador.Mano, jugadas)
+		switch {
+		case len(apertura) == 0:
+			return idioma.T("Aún no tienes ninguna jugada en la mano. Te conviene robar.")
+		case puntos < 30 && len(apertura) == 1:
+			if nivel == PistaCompleta {
+				return idioma.Tf("Todavía no llegas a los 30 puntos para abrir: tu mejor jugada es %v y suma %d.", apertura[0], puntos)
+			}
+			return idioma.Tf("Todavía no llegas a los 30 puntos para abrir: tu mejor jugada suma %d.", puntos)
+		case puntos < 30:
+			if nivel == PistaCompleta {
+				return idioma.Tf("Todavía no llegas a los 30 puntos para abrir: tus mejores jugadas son %s y suman %d.", notacionJugadas(apertura), puntos)
+			}
+			return idioma.Tf("Todavía no llegas a los 30 puntos para abrir: juntando jugadas sumas %d.", puntos)
+		case len(apertura) == 1:
+			if nivel == PistaCompleta {
+				return idioma.Tf("Puedes abrir con %v (%d puntos): juega los índices %v.", apertura[0], puntos, reglas.IndicesEnMano(jugador.Mano, apertura[0]))
+			}
+			return idioma.Tf("Ya puedes hacer tu primera jugada: tienes %s que suma 30 o más puntos.", describirJugada(grupos, apertura[0]))
+		}
+		if nivel == PistaCompleta {
+			return idioma.Tf("Puedes abrir bajando %s (%d puntos en total).", notacionJugadas(apertura), puntos)
+		}
+		return idioma.Tf("Ya puedes hacer tu primera jugada: entre %d jugadas de tu mano sumas 30 o más puntos.", len(apertura))
+	}
+	if jugada := reglas.MejorJugada(jugadas, false); jugada != nil {
+		if nivel == PistaCompleta {
+			return idioma.Tf("Puedes bajar %v: juega los índices %v.", jugada, reglas.IndicesEnMano(jugador.Mano, jugada))
+		}
+		return idioma.Tf("Tienes %s en la mano.", describirJugada(grupos, jugada))
+	}
+	for i, ficha := range jugador.Mano {
+		for j, jugadaEnMesa := range mesa {
+			if grupos.SePuedeAnadirFicha(jugadaEnMesa, ficha) {
+				if nivel == PistaCompleta {
+					return idioma.Tf("Puedes añadir tu %s (índice %d) a la jugada %d.", ficha, i, j)
+				}
+				return idioma.T("Una de tus fichas encaja en alguna jugada de la mesa.")
+			}
+		}
+	}
+	return idioma.T("No encuentro ninguna jugada. Te conviene robar.")
+}
+
+// describirJugada dice qué tipo de jugada es y cuántas fichas tiene, sin nombrarlas.
+func describirJugada(grupos reglas.ReglasGrupos, jugada []mazo.Pieza) string {
+	if grupos.EsTrioValido(append([]mazo.Pieza(nil), jugada...)) {
+		if len(jugada) == 4 {
+			return idioma.T("una cuarteta")
+		}
+		return idioma.T("un trío")
+	}
+	return idioma.Tf("una escalera de %d fichas", len(jugada))
+}
+
+// mejorApertura junta jugadas de la mano que no comparten fichas hasta sumar los 30
+// puntos de la apertura. Prueba primero las candidatas de más valor y, si no llega,
+// devuelve la combinación que más suma.
+func mejorApertura(mano []mazo.Pieza, jugadas [][]mazo.Pieza) ([][]mazo.Pieza, int) {
+	type candidata struct {
+		jugada []mazo.Pieza
+		fichas mazo.ConjuntoFichas
+		puntos int
+	}
+	candidatas := make([]candidata, len(jugadas))
+	for i, jugada := range jugadas {
+		candidatas[i] = candidata{jugada: jugada, fichas: mazo.NuevoConjunto(jugada), puntos: reglas.CalcularValorJugada(jugada)}
+	}
+	sort.SliceStable(candidatas, func(i, j int) bool { return candidatas[i].puntos > candidatas[j].puntos })
+
+	var mejor, elegidas [][]mazo.Pieza
+	mejorPuntos := 0
+	var buscar func(desde int, resto mazo.ConjuntoFichas, puntos int) bool
+	buscar = func(desde int, resto mazo.ConjuntoFichas, puntos int) bool {
+		if puntos > mejorPuntos {
+			mejor, mejorPuntos = append([][]mazo.Pieza(nil), elegidas...), puntos
+		}
+		if mejorPuntos >= 30 {
+			return true
+		}
+		for i := desde; i < len(candidatas); i++ {
+			c := candidatas[i]
+			if !resto.ContieneTodas(c.fichas) {
+				continue
+			}
+			elegidas = append(elegidas, c.jugada)
+			if buscar(i+1, resto.Diferencia(c.fichas), puntos+c.puntos) {
+				return true
+			}
+			elegidas = elegidas[:len(elegidas)-1]
+		}
+		return false
+	}
+	buscar(0, mazo.NuevoConjunto(mano), 0)
+	return mejor, mejorPuntos
+}
+
+// notacionJugadas escribe varias jugadas en notación corta, separadas por " + ".
+func notacionJugadas(jugadas [][]mazo.Pieza) string {
+	partes := make([]string, len(jugadas))
+	for i, jugada := range jugadas {
+		partes[i] = mazo.NotacionFichas(jugada)
+	}
+	return strings.Join(partes, " + ")
+}
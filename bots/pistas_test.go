@@ -0,0 +1,102 @@
+package bots
+
+import (
+	"strings"
+	"testing"
+
+	"com.github/hapkiduki/rummikub/mazo"
+	"com.github/hapkiduki/rummikub/motor"
+	"com.github/hapkiduki/rummikub/reglas"
+)
+
+func TestDarPista(t *testing.T) {
+	comodin := mazo.Pieza{Color: -1, Numero: 0}
+	casosDePrueba := []struct {
+		nombre   string
+		mano     []mazo.Pieza
+		abierto  bool
+		mesa     [][]mazo.Pieza
+		grupos   reglas.ReglasGrupos
+		nivel    NivelPista
+		contiene string
+	}{
+		{
+			nombre:   "Apertura alcanzable usando el comodín",
+			mano:     []mazo.Pieza{{Color: mazo.Rojo, Numero: 11}, comodin, {Color: mazo.Rojo, Numero: 13}, {Color: mazo.Azul, Numero: 2}},
+			nivel:    PistaCompleta,
+			contiene: "juega los índices [0 1 2]",
+		},
+		{
+			nombre:   "Apertura no alcanzable",
+			mano:     []mazo.Pieza{{Color: mazo.Rojo, Numero: 1}, {Color: mazo.Rojo, Numero: 2}, {Color: mazo.Rojo, Numero: 3}},
+			nivel:    PistaBasica,
+			contiene: "suma 6",
+		},
+		{
+			nombre:   "Apertura sumando dos jugadas",
+			mano:     []mazo.Pieza{{Color: mazo.Rojo, Numero: 5}, {Color: mazo.Rojo, Numero: 6}, {Color: mazo.Rojo, Numero: 7}, {Color: mazo.Azul, Numero: 4}, {Color: mazo.Amarillo, Numero: 4}, {Color: mazo.Negro, Numero: 4}},
+			nivel:    PistaCompleta,
+			contiene: "R5 R6 R7 + B4 Y4 K4 (30",
+		},
+		{
+			nombre:   "Apertura partiendo una cuarteta",
+			mano:     []mazo.Pieza{{Color: mazo.Rojo, Numero: 5}, {Color: mazo.Azul, Numero: 5}, {Color: mazo.Amarillo, Numero: 5}, {Color: mazo.Negro, Numero: 5}, {Color: mazo.Rojo, Numero: 6}, {Color: mazo.Rojo, Numero: 7}},
+			nivel:    PistaBasica,
+			contiene: "entre 2 jugadas",
+		},
+		{
+			nombre:   "Dos jugadas que no llegan a 30",
+			mano:     []mazo.Pieza{{Color: mazo.Rojo, Numero: 1}, {Color: mazo.Rojo, Numero: 2}, {Color: mazo.Rojo, Numero: 3}, {Color: mazo.Azul, Numero: 2}, {Color: mazo.Amarillo, Numero: 2}, {Color: mazo.Negro, Numero: 2}},
+			nivel:    PistaBasica,
+			contiene: "sumas 12",
+		},
+		{
+			nombre:   "Pista básica sin nombrar las fichas",
+			mano:     []mazo.Pieza{{Color: mazo.Rojo, Numero: 10}, {Color: mazo.Azul, Numero: 10}, {Color: mazo.Negro, Numero: 10}},
+			nivel:    PistaBasica,
+			contiene: "un trío",
+		},
+		{
+			nombre:   "Añadir una ficha a la mesa tras abrir",
+			mano:     []mazo.Pieza{{Color: mazo.Azul, Numero: 6}, {Color: mazo.Negro, Numero: 1}},
+			abierto:  true,
+			mesa:     [][]mazo.Pieza{{{Color: mazo.Rojo, Numero: 1}, {Color: mazo.Rojo, Numero: 2}, {Color: mazo.Rojo, Numero: 3}}, {{Color: mazo.Azul, Numero: 3}, {Color: mazo.Azul, Numero: 4}, {Color: mazo.Azul, Numero: 5}}},
+			nivel:    PistaCompleta,
+			contiene: "a la jugada 1",
+		},
+		{
+			nombre:   "Sin jugadas posibles",
+			mano:     []mazo.Pieza{{Color: mazo.Azul, Numero: 6}, {Color: mazo.Negro, Numero: 1}},
+			abierto:  true,
+			nivel:    PistaCompleta,
+			contiene: "robar",
+		},
+		{
+			nombre:   "Trío con dos comodines abierto en el reglamento estándar",
+			mano:     []mazo.Pieza{{Color: mazo.Azul, Numero: 7}},
+			abierto:  true,
+			mesa:     [][]mazo.Pieza{{{Color: mazo.Rojo, Numero: 7}, comodin, comodin}},
+			nivel:    PistaCompleta,
+			contiene: "a la jugada 0",
+		},
+		{
+			nombre:   "Trío con dos comodines cerrado por la variante",
+			mano:     []mazo.Pieza{{Color: mazo.Azul, Numero: 7}},
+			abierto:  true,
+			mesa:     [][]mazo.Pieza{{{Color: mazo.Rojo, Numero: 7}, comodin, comodin}},
+			grupos:   reglas.ReglasGrupos{CerrarConDosComodines: true},
+			nivel:    PistaCompleta,
+			contiene: "robar",
+		},
+	}
+
+	for _, tc := range casosDePrueba {
+		t.Run(tc.nombre, func(t *testing.T) {
+			jugador := &motor.Jugador{Mano: tc.mano, HaHechoPrimeraJugada: tc.abierto}
+			pista := DarPista(jugador, tc.mesa, tc.grupos, tc.nivel)
+			if !strings.Contains(pista, tc.contiene) {
+				t.Errorf("Se esperaba que la pista contuviera %q, pero fue %q", tc.contiene, pista)
+			}
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package bots
+
+// --- REGISTRO DE ESTRATEGIAS ---
+
+import (
+	"sort"
+
+	"com.github/hapkiduki/rummikub/motor"
+)
+
+// estrategiasBot son las estrategias que pueden jugar sin humano, por nombre.
+// Las estrategias nuevas se añaden con Registrar.
+var estrategiasBot = map[string]func() motor.Estrategia{
+	"novato":     func() motor.Estrategia { return EstrategiaNovato{Silencioso: true} },
+	"intermedio": func() motor.Estrategia { return EstrategiaIntermedio{Silencioso: true} },
+}
+
+// Registrar hace que una estrategia pueda participar en simulaciones y
+// torneos con el nombre indicado. crear debe devolver una estrategia que no narre
+// ni haga pausas. Debe llamarse antes de empezar a simular.
+func Registrar(nombre string, crear func() motor.Estrategia) {
+	estrategiasBot[nombre] = crear
+}
+
+// Crear devuelve una estrategia nueva del tipo registrado con ese nombre, o false
+// si no hay ninguna con ese nombre.
+func Crear(nombre string) (motor.Estrategia, bool) {
+	crear, ok := estrategiasBot[nombre]
+	if !ok {
+		return nil, false
+	}
+	return crear(), true
+}
+
+// Nombres devuelve los nombres registrados en orden alfabético.
+func Nombres() []string {
+	nombres := make([]string, 0, len(estrategiasBot))
+	for nombre := range estrategiasBot {
+		nombres = append(nombres, nombre)
+	}
+	sort.Strings(nombres)
+	return nombres
+}
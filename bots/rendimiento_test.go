@@ -0,0 +1,64 @@
+package bots
+
+import (
+	"math/rand"
+	"testing"
+
+	"com.github/hapkiduki/rummikub/mazo"
+	"com.github/hapkiduki/rummikub/reglas"
+)
+
+// --- BENCHMARKS DE LA BÚSQUEDA DE LOS BOTS ---
+//
+// Igual que en reglas, las manos salen de barajar el mazo con semillas fijas:
+//
+//	go test ./bots -run '^$' -bench . -benchmem
+
+// manosDePrueba reparte n manos de tam fichas, cada una de un mazo barajado con su
+// propia semilla. Con comodines, la mano lleva los dos comodines del mazo y el resto
+// son fichas normales.
+func manosDePrueba(n, tam int, comodines bool) [][]mazo.Pieza {
+	manos := make([][]mazo.Pieza, n)
+	for i := range manos {
+		fichas := mazo.Completo()
+		normales, dosComodines := fichas[:len(fichas)-2], fichas[len(fichas)-2:]
+		rand.New(rand.NewSource(int64(i+1))).Shuffle(len(normales), func(a, b int) { normales[a], normales[b] = normales[b], normales[a] })
+		if comodines {
+			manos[i] = append(append([]mazo.Pieza(nil), dosComodines...), normales[:tam-2]...)
+		} else {
+			manos[i] = normales[:tam]
+		}
+	}
+	return manos
+}
+
+// BenchmarkMejorApertura mide la búsqueda de la apertura, que prueba combinaciones de
+// candidatas sin fichas en común y es lo que más puede tardar en un turno con tiempo.
+// Las candidatas se calculan fuera del bucle: JugadasPosibles tiene su propio benchmark.
+func BenchmarkMejorApertura(b *testing.B) {
+	casosDePrueba := []struct {
+		nombre    string
+		fichas    int
+		comodines bool
+	}{
+		{nombre: "ManoInicial", fichas: 14},
+		{nombre: "ManoDe25", fichas: 25},
+		{nombre: "ManoInicialConComodines", fichas: 14, comodines: true},
+		{nombre: "ManoDe25ConComodines", fichas: 25, comodines: true},
+	}
+	for _, tc := range casosDePrueba {
+		b.Run(tc.nombre, func(b *testing.B) {
+			manos := manosDePrueba(16, tc.fichas, tc.comodines)
+			candidatas := make([][][]mazo.Pieza, len(manos))
+			for i, mano := range manos {
+				candidatas[i] = reglas.JugadasPosibles(mano)
+			}
+			b.ReportAllocs()
+			i := 0
+			for b.Loop() {
+				mejorApertura(manos[i%len(manos)], candidatas[i%len(manos)])
+				i++
+			}
+		})
+	}
+}
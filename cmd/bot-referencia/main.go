@@ -0,0 +1,18 @@
+// Bot-referencia es un bot que juega como programa externo: lee los mensajes del
+// motor por su entrada estándar y contesta por la salida estándar. Sirve de ejemplo
+// del protocolo descrito en el paquete externo.
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"com.github/hapkiduki/rummikub/externo"
+)
+
+func main() {
+	if err := externo.JugarReferencia(os.Stdin, os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
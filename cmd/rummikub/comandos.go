@@ -0,0 +1,530 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"com.github/hapkiduki/rummikub/bots"
+	"com.github/hapkiduki/rummikub/idioma"
+	"com.github/hapkiduki/rummikub/mazo"
+	"com.github/hapkiduki/rummikub/motor"
+	"com.github/hapkiduki/rummikub/reglas"
+)
+
+// --- COMANDOS DEL JUGADOR HUMANO ---
+//
+// El jugador humano juega su turno escribiendo comandos ("play R7 R8 R9", "add 3 to 2",
+// "move 2:4 to 5", "split 1 at 3", "done"...). Puede encadenar varios en el mismo
+// turno, deshacerlos con undo y terminar con done; la Partida comprueba la mesa al
+// final como con cualquier otra estrategia.
+
+// TipoComando es el nombre (en inglés) de un comando.
+type TipoComando string
+
+const (
+	ComandoJugar     TipoComando = "play"
+	ComandoAnadir    TipoComando = "add"
+	ComandoMover     TipoComando = "move"
+	ComandoDividir   TipoComando = "split"
+	ComandoRobar     TipoComando = "draw"
+	ComandoTerminar  TipoComando = "done"
+	ComandoDeshacer  TipoComando = "undo"
+	ComandoPista     TipoComando = "hint"
+	ComandoOrden     TipoComando = "order"
+	ComandoMostrar   TipoComando = "show"
+	ComandoHistorial TipoComando = "history"
+	ComandoAyuda     TipoComando = "help"
+	ComandoGuardar   TipoComando = "save"
+)
+
+// definicionComando describe un comando para el intérprete y la ayuda.
+type definicionComando struct {
+	tipo  TipoComando
+	alias []string // Nombres alternativos en español.
+	uso   string
+	ayuda string
+}
+
+var definicionesComandos = []definicionComando{
+	{ComandoJugar, []string{"jugar", "bajar"}, "play <fichas>", "baja una jugada nueva, por índices o en notación (play 0,4,8 o play R7 R8 R9)"},
+	{ComandoAnadir, []string{"anadir", "añadir"}, "add <ficha> to <jugada>", "añade una ficha de tu mano a una jugada de la mesa (add B5 to 3)"},
+	{ComandoMover, []string{"mover"}, "move <jugada>:<posición> to <jugada|new>", "mueve una ficha de la mesa a otra jugada o a una nueva (move 2:4 to 5)"},
+	{ComandoDividir, []string{"dividir"}, "split <jugada> at <posición>", "parte una jugada de la mesa en dos, antes de la posición indicada"},
+	{ComandoRobar, []string{"robar"}, "draw", "no bajas nada y robas una ficha: termina el turno"},
+	{ComandoTerminar, []string{"fin", "terminar"}, "done", "termina el turno (si no has bajado nada, robas)"},
+	{ComandoDeshacer, []string{"deshacer"}, "undo", "deshace el último movimiento del turno"},
+	{ComandoPista, []string{"pista"}, "hint", "pide una pista"},
+	{ComandoOrden, []string{"orden"}, "order", "cambia el orden en que se muestra tu mano"},
+	{ComandoMostrar, []string{"mostrar", "ver"}, "show", "vuelve a mostrar la mesa y tu mano"},
+	{ComandoHistorial, []string{"historial"}, "history", "muestra los comandos que has escrito"},
+	{ComandoAyuda, []string{"ayuda", "?"}, "help", "muestra esta ayuda"},
+	{ComandoGuardar, []string{"guardar"}, "save [archivo]", "guarda la partida como estaba al empezar tu turno (por defecto en " + archivoGuardadoPorDefecto + "); se sigue con -cargar"},
+}
+
+// archivoGuardadoPorDefecto es donde save guarda la partida si no se indica archivo.
+// Se vuelve a abrir con el flag -cargar.
+const archivoGuardadoPorDefecto = "rummikub-partida.json"
+
+// Comando es una orden del jugador ya interpretada.
+type Comando struct {
+	Tipo     TipoComando
+	Fichas   string // play y add: fichas por índice o en notación, sin resolver.
+	Jugada   int    // add: jugada de destino; move: jugada de origen; split: jugada a partir.
+	Posicion int    // move: posición de la ficha en su jugada; split: dónde se parte.
+	Destino  int    // move: jugada de destino, o -1 para empezar una nueva.
+	Archivo  string // save: archivo donde guardar.
+}
+
+// parsearComando interpreta una línea escrita por el jugador. Los errores explican
+// el uso correcto o sugieren el comando que el jugador quería escribir.
+func parsearComando(linea string) (Comando, error) {
+	palabras := strings.Fields(linea)
+	if len(palabras) == 0 {
+		return Comando{}, errors.New(idioma.T("escribe un comando (help para ver la lista)"))
+	}
+	def, ok := buscarComando(palabras[0])
+	if !ok {
+		if parecido := comandoParecido(palabras[0]); parecido != "" {
+			return Comando{}, fmt.Errorf(idioma.T("comando desconocido '%s'; ¿quisiste decir %s?"), palabras[0], parecido)
+		}
+		return Comando{}, fmt.Errorf(idioma.T("comando desconocido '%s' (help para ver la lista)"), palabras[0])
+	}
+	args := palabras[1:]
+	errUso := fmt.Errorf(idioma.T("uso: %s"), idioma.T(def.uso))
+	c := Comando{Tipo: def.tipo}
+	var err error
+	switch def.tipo {
+	case ComandoJugar:
+		if len(args) == 0 {
+			return Comando{}, errUso
+		}
+		c.Fichas = strings.Join(args, " ")
+	case ComandoAnadir:
+		if len(args) != 3 || !esConector(args[1], "to", "a") {
+			return Comando{}, errUso
+		}
+		c.Fichas = args[0]
+		if c.Jugada, err = numeroDeComando(args[2]); err != nil {
+			return Comando{}, err
+		}
+	case ComandoMover:
+		if len(args) != 3 || !esConector(args[1], "to", "a") {
+			return Comando{}, errUso
+		}
+		jugada, posicion, ok := strings.Cut(args[0], ":")
+		if !ok {
+			return Comando{}, errUso
+		}
+		if c.Jugada, err = numeroDeComando(jugada); err != nil {
+			return Comando{}, err
+		}
+		if c.Posicion, err = numeroDeComando(posicion); err != nil {
+			return Comando{}, err
+		}
+		if esConector(args[2], "new", "nueva") {
+			c.Destino = -1
+		} else if c.Destino, err = numeroDeComando(args[2]); err != nil {
+			return Comando{}, err
+		}
+	case ComandoDividir:
+		if len(args) != 3 || !esConector(args[1], "at", "en") {
+			return Comando{}, errUso
+		}
+		if c.Jugada, err = numeroDeComando(args[0]); err != nil {
+			return Comando{}, err
+		}
+		if c.Posicion, err = numeroDeComando(args[2]); err != nil {
+			return Comando{}, err
+		}
+	case ComandoGuardar:
+		if len(args) > 1 {
+			return Comando{}, errUso
+		}
+		c.Archivo = archivoGuardadoPorDefecto
+		if len(args) == 1 {
+			c.Archivo = args[0]
+		}
+	default:
+		if len(args) != 0 {
+			return Comando{}, errUso
+		}
+	}
+	return c, nil
+}
+
+// buscarComando encuentra un comando por su nombre o por uno de sus alias.
+func buscarComando(nombre string) (definicionComando, bool) {
+	nombre = strings.ToLower(nombre)
+	for _, def := range definicionesComandos {
+		if string(def.tipo) == nombre {
+			return def, true
+		}
+		for _, alias := range def.alias {
+			if alias == nombre {
+				return def, true
+			}
+		}
+	}
+	return definicionComando{}, false
+}
+
+func esConector(palabra string, opciones ...string) bool {
+	for _, opcion := range opciones {
+		if strings.EqualFold(palabra, opcion) {
+			return true
+		}
+	}
+	return false
+}
+
+func numeroDeComando(texto string) (int, error) {
+	n, err := strconv.Atoi(texto)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf(idioma.T("'%s' no es un número de jugada o posición válido"), texto)
+	}
+	return n, nil
+}
+
+// sugerenciasComando devuelve los comandos que empiezan por el prefijo.
+func sugerenciasComando(prefijo string) []string {
+	prefijo = strings.ToLower(prefijo)
+	sugerencias := make([]string, 0)
+	for _, def := range definicionesComandos {
+		if strings.HasPrefix(string(def.tipo), prefijo) {
+			sugerencias = append(sugerencias, string(def.tipo))
+		}
+	}
+	return sugerencias
+}
+
+// comandoParecido devuelve el comando que el jugador seguramente quería escribir:
+// el único que empieza por lo escrito o, si no, el que está a una o dos letras.
+func comandoParecido(nombre string) string {
+	if sugerencias := sugerenciasComando(nombre); len(sugerencias) == 1 {
+		return sugerencias[0]
+	}
+	mejor, menor := "", 3
+	for _, def := range definicionesComandos {
+		if d := distanciaEdicion(strings.ToLower(nombre), string(def.tipo)); d < menor {
+			mejor, menor = string(def.tipo), d
+		}
+	}
+	return mejor
+}
+
+// distanciaEdicion es la distancia de Levenshtein entre dos palabras.
+func distanciaEdicion(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	anterior := make([]int, len(rb)+1)
+	for j := range anterior {
+		anterior[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		actual := make([]int, len(rb)+1)
+		actual[0] = i
+		for j := 1; j <= len(rb); j++ {
+			coste := 1
+			if ra[i-1] == rb[j-1] {
+				coste = 0
+			}
+			actual[j] = min(anterior[j]+1, actual[j-1]+1, anterior[j-1]+coste)
+		}
+		anterior = actual
+	}
+	return anterior[len(rb)]
+}
+
+// completarComando hace de tabulador: completa el nombre del comando si solo hay uno
+// posible y devuelve las sugerencias (los comandos posibles o el uso del comando).
+func completarComando(linea string) (string, []string) {
+	nombre, _, tieneArgs := strings.Cut(strings.TrimLeft(linea, " "), " ")
+	if tieneArgs {
+		if def, ok := buscarComando(nombre); ok {
+			return linea, []string{idioma.T(def.uso)}
+		}
+		return linea, nil
+	}
+	sugerencias := sugerenciasComando(nombre)
+	switch len(sugerencias) {
+	case 0:
+		if parecido := comandoParecido(nombre); parecido != "" {
+			return linea, []string{parecido}
+		}
+		return linea, nil
+	case 1:
+		return sugerencias[0] + " ", sugerencias
+	}
+	comun := sugerencias[0]
+	for _, s := range sugerencias[1:] {
+		for !strings.HasPrefix(s, comun) {
+			comun = comun[:len(comun)-1]
+		}
+	}
+	return comun, sugerencias
+}
+
+// ayudaComandos devuelve la lista de comandos con su uso, una línea por comando.
+func ayudaComandos() string {
+	var b strings.Builder
+	for _, def := range definicionesComandos {
+		fmt.Fprintf(&b, "  %-44s %s\n", idioma.T(def.uso), idioma.T(def.ayuda))
+	}
+	b.WriteString(idioma.T("  Las jugadas y las posiciones se cuentan desde 0, como se muestran en la mesa.\n"))
+	return b.String()
+}
+
+// --- HISTORIAL ---
+
+// HistorialComandos recuerda lo que ha escrito el jugador durante toda la partida.
+type HistorialComandos struct {
+	lineas []string
+}
+
+// Agregar apunta una línea, salvo que repita la anterior.
+func (h *HistorialComandos) Agregar(linea string) {
+	if linea == "" || len(h.lineas) > 0 && h.lineas[len(h.lineas)-1] == linea {
+		return
+	}
+	h.lineas = append(h.lineas, linea)
+}
+
+// Lineas devuelve una copia del historial, de la más antigua a la más reciente.
+func (h *HistorialComandos) Lineas() []string {
+	return append([]string(nil), h.lineas...)
+}
+
+// --- EJECUCIÓN ---
+
+// turnoHumano lleva el turno de un jugador humano: la mesa con sus cambios y las
+// fotos necesarias para deshacerlos.
+type turnoHumano struct {
+	jugador  *motor.Jugador
+	mesa     [][]mazo.Pieza
+	partida  *motor.Partida  // Puede ser nil (sin reglas de la casa ni guardado).
+	marcador *motor.Marcador // Para el panel de estado; puede ser nil.
+	pistas   bots.NivelPista
+	inicio   motor.InicioTurno
+	deshacer []motor.InicioTurno
+}
+
+func nuevoTurnoHumano(jugador *motor.Jugador, mesa [][]mazo.Pieza, partida *motor.Partida, pistas bots.NivelPista) *turnoHumano {
+	return &turnoHumano{jugador: jugador, mesa: mesa, partida: partida, pistas: pistas, inicio: motor.FotoDelTurno(jugador, mesa)}
+}
+
+// casa devuelve las reglas de la partida, o el reglamento estándar si no hay partida.
+func (t *turnoHumano) casa() motor.ReglasCasa {
+	if t.partida == nil {
+		return motor.ReglasCasa{}
+	}
+	return t.partida.Reglas
+}
+
+// ejecutar aplica un comando. Devuelve el mensaje para el jugador y si su turno ha
+// terminado. show, help e history los resuelve cada interfaz a su manera.
+func (t *turnoHumano) ejecutar(c Comando) (string, bool, error) {
+	switch c.Tipo {
+	case ComandoJugar:
+		indices, err := indicesDeEntrada(t.jugador.Mano, c.Fichas)
+		if err != nil {
+			return "", false, err
+		}
+		return t.aplicar(motor.Movimiento{Accion: motor.MovimientoJugar, Indices: indices})
+	case ComandoAnadir:
+		indices, err := indicesDeEntrada(t.jugador.Mano, c.Fichas)
+		if err != nil {
+			return "", false, err
+		}
+		return t.aplicar(motor.Movimiento{Accion: motor.MovimientoAnadir, Indices: indices, Jugada: c.Jugada})
+	case ComandoMover:
+		return t.moverFicha(c.Jugada, c.Posicion, c.Destino)
+	case ComandoDividir:
+		return t.dividir(c.Jugada, c.Posicion)
+	case ComandoRobar:
+		if len(t.deshacer) > 0 {
+			return "", false, errors.New(idioma.T("ya has movido fichas en este turno: usa done para terminar o undo para deshacer"))
+		}
+		return idioma.T("Tu turno ha terminado."), true, nil
+	case ComandoTerminar:
+		if err := t.inicio.Validar(t.casa().Grupos, t.jugador, t.mesa, nil); err != nil {
+			return "", false, fmt.Errorf(idioma.T("%v; arréglalo o usa undo"), err)
+		}
+		return idioma.T("Tu turno ha terminado."), true, nil
+	case ComandoDeshacer:
+		if len(t.deshacer) == 0 {
+			return "", false, errors.New(idioma.T("no hay nada que deshacer en este turno"))
+		}
+		foto := t.deshacer[len(t.deshacer)-1]
+		t.deshacer = t.deshacer[:len(t.deshacer)-1]
+		t.mesa = motor.CopiarMesa(foto.Mesa)
+		t.jugador.Mano = append([]mazo.Pieza(nil), foto.Mano...)
+		t.jugador.HaHechoPrimeraJugada = foto.Abierto
+		return idioma.T("Movimiento deshecho."), false, nil
+	case ComandoPista:
+		if t.pistas == bots.PistasDesactivadas {
+			return "", false, errors.New(idioma.T("las pistas están desactivadas en esta partida"))
+		}
+		t.jugador.PistasUsadas++
+		return idioma.Tf("Pista: %s", bots.DarPista(t.jugador, t.mesa, t.casa().Grupos, t.pistas)), false, nil
+	case ComandoOrden:
+		t.jugador.OrdenMano = t.jugador.OrdenMano.Siguiente()
+		return idioma.Tf("Tu mano se ordena %s.", t.jugador.OrdenMano), false, nil
+	case ComandoGuardar:
+		return t.guardar(c.Archivo)
+	}
+	return "", false, nil
+}
+
+// aplicar hace un movimiento de la mano a la mesa y guarda cómo deshacerlo.
+func (t *turnoHumano) aplicar(mov motor.Movimiento) (string, bool, error) {
+	foto := motor.FotoDelTurno(t.jugador, t.mesa)
+	mesa, err := t.casa().AplicarMovimiento(t.jugador, t.mesa, mov)
+	if err != nil {
+		antes := len(t.jugador.Mano)
+		if !t.partida.IntentoInvalido(t.jugador) {
+			return "", false, err
+		}
+		// La penalización termina el turno: se pierde todo lo hecho en él y el jugador
+		// se queda con su mano del principio más las fichas robadas.
+		robadas := t.jugador.Mano[antes:]
+		t.mesa = motor.CopiarMesa(t.inicio.Mesa)
+		t.jugador.Mano = append(append([]mazo.Pieza(nil), t.inicio.Mano...), robadas...)
+		t.jugador.HaHechoPrimeraJugada = t.inicio.Abierto
+		t.deshacer = nil
+		return "", true, err
+	}
+	t.mesa = mesa
+	t.deshacer = append(t.deshacer, foto)
+	switch mov.Accion {
+	case motor.MovimientoJugar:
+		mensaje := idioma.Tf("Has bajado %s como jugada %d.", mazo.NotacionFichas(t.mesa[len(t.mesa)-1]), len(t.mesa)-1)
+		if !foto.Abierto {
+			mensaje += " " + idioma.Tf("¡Felicidades! Has hecho tu primera jugada de %d puntos.", reglas.CalcularValorJugada(t.mesa[len(t.mesa)-1]))
+		}
+		return mensaje, false, nil
+	case motor.MovimientoAnadir:
+		return idioma.Tf("La jugada %d queda así: %s.", mov.Jugada, mazo.NotacionFichas(t.mesa[mov.Jugada])), false, nil
+	}
+	return "", false, nil
+}
+
+// tocarMesa comprueba que el jugador puede reorganizar la mesa y que la jugada existe.
+func (t *turnoHumano) tocarMesa(jugada int) error {
+	if !t.inicio.Abierto {
+		return errors.New(idioma.T("no puedes tocar las jugadas de la mesa antes de tu primera jugada"))
+	}
+	if jugada < 0 || jugada >= len(t.mesa) {
+		return fmt.Errorf(idioma.T("la jugada %d no existe en la mesa"), jugada)
+	}
+	return nil
+}
+
+// moverFicha pasa una ficha de una jugada de la mesa a otra, o a una jugada nueva si
+// destino es -1. Las jugadas pueden quedar inválidas mientras dura el turno.
+func (t *turnoHumano) moverFicha(origen, posicion, destino int) (string, bool, error) {
+	if err := t.tocarMesa(origen); err != nil {
+		return "", false, err
+	}
+	if posicion >= len(t.mesa[origen]) {
+		return "", false, fmt.Errorf(idioma.T("la jugada %d no tiene posición %d"), origen, posicion)
+	}
+	if destino != -1 {
+		if err := t.tocarMesa(destino); err != nil {
+			return "", false, err
+		}
+		if destino == origen {
+			return "", false, errors.New(idioma.T("la ficha ya está en esa jugada"))
+		}
+	}
+	t.deshacer = append(t.deshacer, motor.FotoDelTurno(t.jugador, t.mesa))
+	t.mesa = motor.CopiarMesa(t.mesa)
+	ficha := t.mesa[origen][posicion]
+	t.mesa[origen] = append(t.mesa[origen][:posicion], t.mesa[origen][posicion+1:]...)
+	if destino == -1 {
+		t.mesa = append(t.mesa, []mazo.Pieza{ficha})
+		destino = len(t.mesa) - 1
+	} else {
+		t.mesa[destino] = append(t.mesa[destino], ficha)
+		reglas.OrdenarJugada(t.mesa[destino])
+	}
+	if len(t.mesa[origen]) == 0 {
+		t.mesa = append(t.mesa[:origen], t.mesa[origen+1:]...)
+		if destino > origen {
+			destino--
+		}
+	}
+	return idioma.Tf("Has movido %s a la jugada %d.", ficha.Notacion(), destino) + t.avisoMesa(), false, nil
+}
+
+// dividir parte una jugada de la mesa en dos justo antes de la posición indicada.
+func (t *turnoHumano) dividir(jugada, posicion int) (string, bool, error) {
+	if err := t.tocarMesa(jugada); err != nil {
+		return "", false, err
+	}
+	if posicion <= 0 || posicion >= len(t.mesa[jugada]) {
+		return "", false, fmt.Errorf(idioma.T("para partir la jugada %d la posición debe estar entre 1 y %d"), jugada, len(t.mesa[jugada])-1)
+	}
+	t.deshacer = append(t.deshacer, motor.FotoDelTurno(t.jugador, t.mesa))
+	t.mesa = motor.CopiarMesa(t.mesa)
+	primera := t.mesa[jugada][:posicion:posicion]
+	segunda := t.mesa[jugada][posicion:]
+	t.mesa = append(t.mesa[:jugada], append([][]mazo.Pieza{primera, segunda}, t.mesa[jugada+1:]...)...)
+	return idioma.Tf("La jugada %d queda partida en %s y %s.", jugada, mazo.NotacionFichas(primera), mazo.NotacionFichas(segunda)) + t.avisoMesa(), false, nil
+}
+
+// avisoMesa recuerda qué jugadas hay que arreglar antes de terminar el turno.
+func (t *turnoHumano) avisoMesa() string {
+	grupos := t.casa().Grupos
+	invalidas := make([]string, 0)
+	for i, jugada := range t.mesa {
+		if !grupos.EsJugadaValida(jugada) {
+			invalidas = append(invalidas, strconv.Itoa(i))
+		}
+	}
+	if len(invalidas) == 0 {
+		return ""
+	}
+	return " " + idioma.Tf("Antes de terminar arregla las jugadas %s.", strings.Join(invalidas, ", "))
+}
+
+// guardar escribe la partida como estaba al empezar el turno, sin los cambios a medias.
+func (t *turnoHumano) guardar(archivo string) (string, bool, error) {
+	if t.partida == nil {
+		return "", false, errors.New(idioma.T("no hay ninguna partida que guardar"))
+	}
+	guardada := t.partida.Guardada()
+	guardada.Mesa = make([]string, len(t.inicio.Mesa))
+	for i, jugada := range t.inicio.Mesa {
+		guardada.Mesa[i] = mazo.NotacionFichasConID(jugada)
+	}
+	for i, j := range t.partida.Jugadores {
+		if j == t.jugador {
+			guardada.Jugadores[i].Mano = mazo.NotacionFichasConID(t.inicio.Mano)
+			guardada.Jugadores[i].Abierto = t.inicio.Abierto
+		}
+	}
+	datos, err := json.MarshalIndent(guardada, "", "  ")
+	if err != nil {
+		return "", false, err
+	}
+	if err := os.WriteFile(archivo, append(datos, '\n'), 0o644); err != nil {
+		return "", false, err
+	}
+	return idioma.Tf("Partida guardada en %s.", archivo), false, nil
+}
+
+// leerPartidaGuardada lee un archivo escrito por save.
+func leerPartidaGuardada(archivo string) (*motor.PartidaGuardada, error) {
+	datos, err := os.ReadFile(archivo)
+	if err != nil {
+		return nil, err
+	}
+	var guardada motor.PartidaGuardada
+	if err := json.Unmarshal(datos, &guardada); err != nil {
+		return nil, err
+	}
+	return &guardada, nil
+}
@@ -0,0 +1,179 @@
+package main
+
+import (
+	"math/rand"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"com.github/hapkiduki/rummikub/bots"
+	"com.github/hapkiduki/rummikub/idioma"
+	"com.github/hapkiduki/rummikub/mazo"
+	"com.github/hapkiduki/rummikub/motor"
+)
+
+func TestParsearComando(t *testing.T) {
+	casosDePrueba := []struct {
+		linea    string
+		esperado Comando
+		error    string // Un trozo del mensaje de error, o vacío si es válido.
+	}{
+		{linea: "play 0,4,8", esperado: Comando{Tipo: ComandoJugar, Fichas: "0,4,8"}},
+		{linea: "PLAY R7 R8 R9", esperado: Comando{Tipo: ComandoJugar, Fichas: "R7 R8 R9"}},
+		{linea: "add 3 to 2", esperado: Comando{Tipo: ComandoAnadir, Fichas: "3", Jugada: 2}},
+		{linea: "añadir B5 a 1", esperado: Comando{Tipo: ComandoAnadir, Fichas: "B5", Jugada: 1}},
+		{linea: "move 2:4 to 5", esperado: Comando{Tipo: ComandoMover, Jugada: 2, Posicion: 4, Destino: 5}},
+		{linea: "move 0:1 to new", esperado: Comando{Tipo: ComandoMover, Posicion: 1, Destino: -1}},
+		{linea: "split 1 at 3", esperado: Comando{Tipo: ComandoDividir, Jugada: 1, Posicion: 3}},
+		{linea: "save", esperado: Comando{Tipo: ComandoGuardar, Archivo: archivoGuardadoPorDefecto}},
+		{linea: "draw", esperado: Comando{Tipo: ComandoRobar}},
+		{linea: "play", error: "uso: play <fichas>"},
+		{linea: "add 3 2", error: "uso: add <ficha> to <jugada>"},
+		{linea: "move 2 to 5", error: "uso: move"},
+		{linea: "split x at 3", error: "'x' no es un número"},
+		{linea: "draw now", error: "uso: draw"},
+		{linea: "pley 1 2 3", error: "¿quisiste decir play?"},
+		{linea: "sp", error: "¿quisiste decir split?"},
+		{linea: "", error: "escribe un comando"},
+	}
+	for _, tc := range casosDePrueba {
+		comando, err := parsearComando(tc.linea)
+		if tc.error != "" {
+			if err == nil || !strings.Contains(err.Error(), tc.error) {
+				t.Errorf("Se esperaba un error con %q para %q, pero fue %v", tc.error, tc.linea, err)
+			}
+			continue
+		}
+		if err != nil || comando != tc.esperado {
+			t.Errorf("Se esperaba %+v para %q, pero fue %+v (%v)", tc.esperado, tc.linea, comando, err)
+		}
+	}
+}
+
+// TestAyudaComandosEnIngles comprueba que el catálogo en inglés traduce la ayuda de
+// cada comando. El resto de textos los revisa el test del paquete idioma.
+func TestAyudaComandosEnIngles(t *testing.T) {
+	defer idioma.Usar(idioma.Actual())
+	idioma.Usar(idioma.Ingles)
+	for _, def := range definicionesComandos {
+		if def.uso != string(def.tipo) && idioma.T(def.uso) == def.uso {
+			t.Errorf("%s: falta la traducción al inglés de %q", def.tipo, def.uso)
+		}
+		if idioma.T(def.ayuda) == def.ayuda {
+			t.Errorf("%s: falta la traducción al inglés de %q", def.tipo, def.ayuda)
+		}
+	}
+}
+
+func TestCompletarComando(t *testing.T) {
+	casosDePrueba := []struct {
+		linea       string
+		completada  string
+		sugerencias []string
+	}{
+		{"pl", "play ", []string{"play"}},
+		{"d", "d", []string{"draw", "done"}},
+		{"h", "h", []string{"hint", "history", "help"}},
+		{"hi", "hi", []string{"hint", "history"}},
+		{"move ", "move ", []string{"move <jugada>:<posición> to <jugada|new>"}},
+	}
+	for _, tc := range casosDePrueba {
+		completada, sugerencias := completarComando(tc.linea)
+		if completada != tc.completada || !reflect.DeepEqual(sugerencias, tc.sugerencias) {
+			t.Errorf("Se esperaba %q %v para %q, pero fue %q %v", tc.completada, tc.sugerencias, tc.linea, completada, sugerencias)
+		}
+	}
+}
+
+// mesaDe prepara una mesa con las jugadas en notación separadas por '|'.
+func mesaDe(t *testing.T, notacion string) [][]mazo.Pieza {
+	t.Helper()
+	mesa := make([][]mazo.Pieza, 0)
+	for _, jugada := range strings.Split(notacion, "|") {
+		mesa = append(mesa, fichasDe(t, jugada))
+	}
+	return mesa
+}
+
+func TestTurnoHumano(t *testing.T) {
+	jugador := &motor.Jugador{Nombre: "Ana", Mano: fichasDe(t, "R7 B8 K1"), HaHechoPrimeraJugada: true}
+	turno := nuevoTurnoHumano(jugador, mesaDe(t, "R3 R4 R5 R6 | B7 Y7 K7"), nil, bots.PistaCompleta)
+	pasos := []struct {
+		linea     string
+		mesa      string // Cómo queda la mesa después del comando.
+		error     bool
+		terminado bool
+	}{
+		{linea: "split 0 at 2", mesa: "R3 R4 | R5 R6 | B7 Y7 K7"},
+		{linea: "done", mesa: "R3 R4 | R5 R6 | B7 Y7 K7", error: true},
+		{linea: "draw", mesa: "R3 R4 | R5 R6 | B7 Y7 K7", error: true},
+		{linea: "undo", mesa: "R3 R4 R5 R6 | B7 Y7 K7"},
+		{linea: "add R7 to 0", mesa: "R3 R4 R5 R6 R7 | B7 Y7 K7"},
+		{linea: "move 0:0 to new", mesa: "R4 R5 R6 R7 | B7 Y7 K7 | R3"},
+		{linea: "move 2:0 to 0", mesa: "R3 R4 R5 R6 R7 | B7 Y7 K7"},
+		{linea: "add B8 to 1", mesa: "R3 R4 R5 R6 R7 | B7 Y7 K7", error: true},
+		{linea: "undo", mesa: "R4 R5 R6 R7 | B7 Y7 K7 | R3"},
+		{linea: "undo", mesa: "R3 R4 R5 R6 R7 | B7 Y7 K7"},
+		{linea: "done", mesa: "R3 R4 R5 R6 R7 | B7 Y7 K7", terminado: true},
+	}
+	for _, paso := range pasos {
+		comando, err := parsearComando(paso.linea)
+		if err != nil {
+			t.Fatalf("No se pudo interpretar %q: %v", paso.linea, err)
+		}
+		_, terminado, err := turno.ejecutar(comando)
+		if (err != nil) != paso.error || terminado != paso.terminado {
+			t.Errorf("%s: se esperaba error %v y terminado %v, pero fue %v y %v", paso.linea, paso.error, paso.terminado, err, terminado)
+		}
+		if mesa := mesaDe(t, paso.mesa); !reflect.DeepEqual(turno.mesa, mesa) {
+			t.Errorf("%s: se esperaba la mesa %v, pero fue %v", paso.linea, mesa, turno.mesa)
+		}
+	}
+	if len(jugador.Mano) != 2 {
+		t.Errorf("Se esperaba que Ana se quedara con 2 fichas, pero tiene %v", jugador.Mano)
+	}
+}
+
+func TestPenalizacionDeshaceElTurno(t *testing.T) {
+	ana := &motor.Jugador{Nombre: "Ana", HaHechoPrimeraJugada: true}
+	partida := motor.NuevaPartida([]*motor.Jugador{ana, {Nombre: "Luis"}}, motor.OpcionesPartida{Silenciosa: true, Reglas: motor.ReglasCasa{PenalizacionInvalida: 2}})
+	ana.Mano = append(ana.Mano, fichasDe(t, "R10 R11 R12")...)
+	mano := append([]mazo.Pieza(nil), ana.Mano...)
+	turno := nuevoTurnoHumano(ana, nil, partida, bots.PistaCompleta)
+	// Una jugada válida y después un movimiento inválido: la penalización deshace las dos.
+	for _, linea := range []string{"play R10 R11 R12", "play 0"} {
+		comando, err := parsearComando(linea)
+		if err != nil {
+			t.Fatalf("No se pudo interpretar %q: %v", linea, err)
+		}
+		if _, terminado, _ := turno.ejecutar(comando); terminado != (linea == "play 0") {
+			t.Fatalf("%s: no se esperaba terminado %v", linea, terminado)
+		}
+	}
+	if len(turno.mesa) != 0 || len(ana.Mano) != len(mano)+2 || !reflect.DeepEqual(ana.Mano[:len(mano)], mano) {
+		t.Errorf("Se esperaba la mesa vacía y la mano inicial más 2 fichas, pero la mesa es %v y la mano %v", turno.mesa, ana.Mano)
+	}
+}
+
+func TestGuardarYCargar(t *testing.T) {
+	ana := &motor.Jugador{Nombre: "Ana"}
+	partida := motor.NuevaPartida([]*motor.Jugador{ana, {Nombre: "Luis"}}, motor.OpcionesPartida{Rand: rand.New(rand.NewSource(3)), Silenciosa: true})
+	turno := nuevoTurnoHumano(ana, partida.Mesa, partida, bots.PistaCompleta)
+	archivo := filepath.Join(t.TempDir(), "partida.json")
+	if _, _, err := turno.ejecutar(Comando{Tipo: ComandoGuardar, Archivo: archivo}); err != nil {
+		t.Fatalf("No se pudo guardar la partida: %v", err)
+	}
+	guardada, err := leerPartidaGuardada(archivo)
+	if err != nil {
+		t.Fatalf("No se pudo leer la partida guardada: %v", err)
+	}
+	cargada, err := motor.Cargar(*guardada, []*motor.Jugador{{}, {}}, motor.OpcionesPartida{Silenciosa: true})
+	if err != nil {
+		t.Fatalf("No se pudo cargar la partida guardada: %v", err)
+	}
+	// El hash incluye los IDs: las dos copias de cada ficha deben seguir donde estaban.
+	if cargada.HashEstado() != partida.HashEstado() {
+		t.Errorf("Se esperaba la misma partida al cargarla, pero es %+v", cargada.Guardada())
+	}
+}
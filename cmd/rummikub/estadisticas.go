@@ -0,0 +1,107 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"com.github/hapkiduki/rummikub/idioma"
+	"com.github/hapkiduki/rummikub/mazo"
+	"com.github/hapkiduki/rummikub/perfiles"
+)
+
+// --- ESTADÍSTICAS DE LOS PERFILES ---
+
+// perfilPorDefecto es el perfil del jugador humano si no se indica otro: el usuario
+// del sistema.
+func perfilPorDefecto() string {
+	for _, variable := range []string{"USER", "USERNAME"} {
+		if usuario := os.Getenv(variable); usuario != "" {
+			return usuario
+		}
+	}
+	return "jugador"
+}
+
+// flagEstadisticas define -estadisticas, el archivo de perfiles, en flags.
+func flagEstadisticas(flags *flag.FlagSet) *string {
+	ruta, _ := perfiles.RutaPorDefecto()
+	return flags.String("estadisticas", ruta, idioma.T("archivo JSON con las estadísticas de los perfiles"))
+}
+
+// rivalesConsola es el nombre registrado de la estrategia de cada asiento de la
+// partida por consola, para las estadísticas.
+func rivalesConsola(numJugadores int) []string {
+	rivales := make([]string, numJugadores)
+	for i := range rivales {
+		rivales[i] = asientosConsola[i].nombre
+	}
+	return rivales
+}
+
+// ejecutarEstadisticas es el subcomando "estadisticas": muestra los perfiles
+// indicados, o todos.
+func ejecutarEstadisticas(args []string, salida io.Writer) error {
+	flags := flag.NewFlagSet("estadisticas", flag.ContinueOnError)
+	ruta := flagEstadisticas(flags)
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *ruta == "" {
+		return errors.New(idioma.T("falta el archivo de estadísticas"))
+	}
+	almacen, err := perfiles.Abrir(*ruta)
+	if err != nil {
+		return err
+	}
+	elegidos := make(map[string]bool)
+	for _, nombre := range flags.Args() {
+		elegidos[nombre] = true
+	}
+	mostrados := 0
+	for _, p := range almacen.Perfiles() {
+		if len(elegidos) > 0 && !elegidos[p.Nombre] {
+			continue
+		}
+		mostrarPerfil(salida, p)
+		mostrados++
+	}
+	if mostrados == 0 {
+		fmt.Fprintln(salida, idioma.T("Todavía no hay estadísticas."))
+	}
+	return nil
+}
+
+// mostrarPerfil escribe las estadísticas de un perfil.
+func mostrarPerfil(salida io.Writer, p perfiles.Perfil) {
+	fmt.Fprintf(salida, idioma.T("--- Perfil: %s ---\n"), p.Nombre)
+	porcentaje := 0.0
+	if p.Partidas > 0 {
+		porcentaje = 100 * float64(p.Victorias) / float64(p.Partidas)
+	}
+	fmt.Fprintf(salida, idioma.T("Partidas: %d, victorias: %d (%.0f%%)\n"), p.Partidas, p.Victorias, porcentaje)
+	fmt.Fprintf(salida, idioma.T("Puntos en la mano al acabar, de media: %.1f\n"), p.MediaPuntosEnMano())
+	if len(p.VictoriasMasRapidas) > 0 {
+		turnos := make([]string, len(p.VictoriasMasRapidas))
+		for i, t := range p.VictoriasMasRapidas {
+			turnos[i] = fmt.Sprint(t)
+		}
+		fmt.Fprintf(salida, idioma.T("Victorias más rápidas (turnos): %s\n"), strings.Join(turnos, ", "))
+	}
+	if len(p.EscaleraMasLarga) > 0 {
+		fmt.Fprintf(salida, idioma.T("Escalera más larga: %s (%d fichas)\n"), mazo.NotacionFichas(p.EscaleraMasLarga), len(p.EscaleraMasLarga))
+	}
+	rivales := make([]string, 0, len(p.Rivales))
+	for rival := range p.Rivales {
+		rivales = append(rivales, rival)
+	}
+	sort.Strings(rivales)
+	for _, rival := range rivales {
+		contra := p.Rivales[rival]
+		fmt.Fprintf(salida, idioma.T("  contra %-12s %d partidas, %d victorias\n"), rival, contra.Partidas, contra.Victorias)
+	}
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestEjecutarEstadisticas(t *testing.T) {
+	ruta := filepath.Join(t.TempDir(), "perfiles.json")
+	datos := `[{"nombre":"ana","partidas":4,"victorias":1,"puntos_en_mano":50,"rivales":{"novato":{"partidas":4,"victorias":1}},"victorias_mas_rapidas":[11],"escalera_mas_larga":[{"id":1,"color":0,"numero":1},{"id":2,"color":0,"numero":2},{"id":3,"color":0,"numero":3}]},{"nombre":"luis","partidas":1}]`
+	if err := os.WriteFile(ruta, []byte(datos), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	casosDePrueba := []struct {
+		args      []string
+		contiene  []string
+		excluidos []string
+	}{
+		{
+			args:     []string{"-estadisticas", ruta},
+			contiene: []string{"Perfil: ana", "Perfil: luis", "Partidas: 4, victorias: 1 (25%)", "de media: 12.5", "(turnos): 11", "(3 fichas)", "contra novato"},
+		},
+		{
+			args:      []string{"-estadisticas", ruta, "luis"},
+			contiene:  []string{"Perfil: luis"},
+			excluidos: []string{"Perfil: ana"},
+		},
+		{
+			args:     []string{"-estadisticas", filepath.Join(t.TempDir(), "no-existe.json")},
+			contiene: []string{"Todavía no hay estadísticas."},
+		},
+	}
+	for _, tc := range casosDePrueba {
+		var salida strings.Builder
+		if err := ejecutarEstadisticas(tc.args, &salida); err != nil {
+			t.Fatalf("Se esperaba mostrar las estadísticas de %v, pero fue %v", tc.args, err)
+		}
+		for _, texto := range tc.contiene {
+			if !strings.Contains(salida.String(), texto) {
+				t.Errorf("Se esperaba %q en la salida de %v, pero fue:\n%s", texto, tc.args, salida.String())
+			}
+		}
+		for _, texto := range tc.excluidos {
+			if strings.Contains(salida.String(), texto) {
+				t.Errorf("No se esperaba %q en la salida de %v, pero fue:\n%s", texto, tc.args, salida.String())
+			}
+		}
+	}
+}
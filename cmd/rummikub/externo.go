@@ -0,0 +1,63 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	"com.github/hapkiduki/rummikub/bots"
+	"com.github/hapkiduki/rummikub/externo"
+	"com.github/hapkiduki/rummikub/idioma"
+	"com.github/hapkiduki/rummikub/motor"
+)
+
+// --- BOTS EXTERNOS ---
+
+// botsExternos es el flag -externo de simular y torneo: cada valor nombre=comando
+// registra un programa externo como estrategia con ese nombre.
+type botsExternos struct{}
+
+func (botsExternos) String() string { return "" }
+
+func (botsExternos) Set(valor string) error {
+	nombre, comando, ok := strings.Cut(valor, "=")
+	nombre = strings.TrimSpace(nombre)
+	argumentos := strings.Fields(comando)
+	if !ok || nombre == "" || len(argumentos) == 0 {
+		return fmt.Errorf(idioma.T("se esperaba nombre=comando, no '%s'"), valor)
+	}
+	bots.Registrar(nombre, func() motor.Estrategia {
+		e := externo.Nueva(argumentos...)
+		e.Silenciosa = true
+		return e
+	})
+	return nil
+}
+
+// ejecutarProbarBot implementa el subcomando "probar-bot": pasa las pruebas de
+// conformidad del protocolo al programa indicado.
+func ejecutarProbarBot(args []string, salida io.Writer) error {
+	flags := flag.NewFlagSet("probar-bot", flag.ContinueOnError)
+	tiempo := flags.Duration("tiempo", externo.TiempoPorDefecto, idioma.T("tiempo máximo para cada respuesta del programa"))
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() == 0 {
+		return errors.New(idioma.T("falta el programa que debe jugar"))
+	}
+	fallos := 0
+	for _, r := range externo.Comprobar(flags.Args(), *tiempo) {
+		if r.Err != nil {
+			fallos++
+			fmt.Fprintf(salida, idioma.T("FALLA %s: %v\n"), idioma.T(r.Nombre), r.Err)
+			continue
+		}
+		fmt.Fprintf(salida, idioma.T("OK    %s\n"), idioma.T(r.Nombre))
+	}
+	if fallos > 0 {
+		return fmt.Errorf(idioma.T("%d pruebas fallidas"), fallos)
+	}
+	return nil
+}
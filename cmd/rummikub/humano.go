@@ -0,0 +1,242 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"com.github/hapkiduki/rummikub/bots"
+	"com.github/hapkiduki/rummikub/idioma"
+	"com.github/hapkiduki/rummikub/mazo"
+	"com.github/hapkiduki/rummikub/motor"
+	"com.github/hapkiduki/rummikub/reglas"
+)
+
+// --- LÓGICA DE CONFIGURACIÓN ---
+
+func obtenerNumeroDeJugadores() int {
+	for {
+		fmt.Print(idioma.T("Introduce el número de jugadores (2-4): "))
+		input, err := entradaEstandar.ReadString('\n')
+		if err != nil && input == "" {
+			os.Exit(0)
+		}
+		input = strings.TrimSpace(input)
+		numJugadores, err := strconv.Atoi(input)
+		if err == nil && numJugadores >= 2 && numJugadores <= 4 {
+			return numJugadores
+		}
+		fmt.Println(idioma.T("Número de jugadores inválido. Debe ser un número entre 2 y 4."))
+	}
+}
+
+// --- LÓGICA DEL JUGADOR HUMANO ---
+
+type EstrategiaHumano struct {
+	Pistas    bots.NivelPista    // Nivel de las pistas que da el comando hint.
+	partida   *motor.Partida     // Para aplicar las reglas de la casa; puede ser nil.
+	historial *HistorialComandos // Lo escrito en turnos anteriores; puede ser nil.
+	marcador  *motor.Marcador    // Puntos de la serie, si se juega más de una ronda; puede ser nil.
+}
+
+// entradaEstandar lee lo que escribe el jugador. Es una sola para no perder lo que
+// ya se ha leído de más cuando la entrada llega por una tubería.
+var entradaEstandar = bufio.NewReader(os.Stdin)
+
+// FichasRobadas le enseña al jugador lo que ha robado al terminar su turno.
+func (e EstrategiaHumano) FichasRobadas(jugador *motor.Jugador, fichas []mazo.Pieza) {
+	for _, ficha := range fichas {
+		fmt.Printf(idioma.T("Has robado un(a) %s.\n"), ficha)
+	}
+}
+
+// JugarTurno lee comandos hasta que el jugador termina su turno.
+func (e EstrategiaHumano) JugarTurno(jugador *motor.Jugador, mesa [][]mazo.Pieza) [][]mazo.Pieza {
+	if e.historial == nil {
+		e.historial = &HistorialComandos{}
+	}
+	turno := nuevoTurnoHumano(jugador, mesa, e.partida, e.Pistas)
+	turno.marcador = e.marcador
+	fmt.Println("\n--------------------")
+	fmt.Printf(idioma.T("--- Es tu turno, %s ---\n"), jugador.Nombre)
+	mostrarTurno(turno)
+	fmt.Println(idioma.T("Escribe un comando (help para ver la lista)."))
+	for {
+		fmt.Print("> ")
+		linea, err := entradaEstandar.ReadString('\n')
+		if err != nil && linea == "" {
+			fmt.Println(idioma.T("\nHas abandonado la partida."))
+			os.Exit(0)
+		}
+		linea = strings.TrimSpace(linea)
+		if linea == "" {
+			continue
+		}
+		e.historial.Agregar(linea)
+		comando, err := parsearComando(linea)
+		if err != nil {
+			fmt.Println(err)
+			continue
+		}
+		switch comando.Tipo {
+		case ComandoMostrar:
+			mostrarTurno(turno)
+			continue
+		case ComandoAyuda:
+			fmt.Print(ayudaComandos())
+			continue
+		case ComandoHistorial:
+			for i, anterior := range e.historial.Lineas() {
+				fmt.Printf(" %3d  %s\n", i+1, anterior)
+			}
+			continue
+		}
+		mensaje, terminado, err := turno.ejecutar(comando)
+		if err != nil {
+			fmt.Printf(idioma.T("No se puede: %v.\n"), err)
+		} else if mensaje != "" {
+			fmt.Println(mensaje)
+		}
+		if terminado {
+			return turno.mesa
+		}
+		if err == nil && comando.Tipo != ComandoPista && comando.Tipo != ComandoGuardar {
+			mostrarTurno(turno)
+		}
+	}
+}
+
+// mostrarTurno enseña cómo van los demás, la mesa, en notación para poder escribir
+// los comandos, y la mano.
+func mostrarTurno(turno *turnoHumano) {
+	if turno.partida != nil {
+		estado := turno.partida.Estado(nil)
+		fmt.Println(idioma.T("\n--- Jugadores ---"))
+		for _, l := range lineasEstado(estado, turno.marcador) {
+			fmt.Println(l)
+		}
+		fmt.Printf(idioma.T("Quedan %d fichas en el pozo.\n"), estado.FichasEnMazo)
+	}
+	fmt.Println(idioma.T("\n--- Mesa de Juego ---"))
+	if len(turno.mesa) == 0 {
+		fmt.Println(idioma.T("La mesa está vacía."))
+	}
+	for i, jugada := range turno.mesa {
+		fmt.Printf(idioma.T("Jugada %d: %s\n"), i, mazo.NotacionFichas(jugada))
+	}
+	fmt.Println("--------------------")
+	mostrarMano(turno.jugador)
+}
+
+// lineasEstado resume a cada jugador en una línea, en el orden de los asientos:
+// cuántas fichas tiene, si ya ha abierto y, en una serie, sus puntos acumulados.
+func lineasEstado(estado motor.EstadoPartida, marcador *motor.Marcador) []string {
+	lineas := make([]string, len(estado.Jugadores))
+	for i, j := range estado.Jugadores {
+		abierto := idioma.T("sin abrir")
+		if j.HaHechoPrimeraJugada {
+			abierto = idioma.T("ha abierto")
+		}
+		turno := " "
+		if j.Nombre == estado.JugadorActual {
+			turno = "▶"
+		}
+		lineas[i] = turno + " " + idioma.Tf("%-16s %2d fichas  (%s)", j.Nombre, j.Fichas, abierto)
+		if marcador != nil && i < len(marcador.Puntos) {
+			lineas[i] += idioma.Tf("  %+d puntos", marcador.Puntos[i])
+		}
+	}
+	return lineas
+}
+
+// mostrarMano ordena la mano según el modo elegido por el jugador y la imprime
+// con los índices que se usan para jugar.
+func mostrarMano(jugador *motor.Jugador) {
+	grupos := reglas.OrdenarMano(jugador.Mano, jugador.OrdenMano)
+	fmt.Printf(idioma.T("Tu mano actual (%s):\n"), jugador.OrdenMano)
+	for g, grupo := range grupos {
+		if g > 0 && jugador.OrdenMano == reglas.OrdenSugerido {
+			fmt.Println(" --")
+		}
+		for _, i := range grupo {
+			fmt.Printf(" %d: %s  %s\n", i, jugador.Mano[i].String(), jugador.Mano[i].Notacion())
+		}
+	}
+}
+
+// asientosConsola son las estrategias de la partida por consola, por asiento, con el
+// nombre con que están registradas, que es el que usan las estadísticas. Los bots
+// hacen pausas para que se pueda seguir la partida.
+var asientosConsola = []struct {
+	nombre     string
+	estrategia motor.Estrategia
+}{
+	{"humano", EstrategiaHumano{}},
+	{"intermedio", bots.EstrategiaIntermedio{}},
+	{"novato", bots.EstrategiaNovato{}},
+	{"intermedio", bots.EstrategiaIntermedio{}},
+}
+
+// crearJugadores está relacionado con el tipo Jugador. Los bots intermedios usan
+// las variantes de grupos de la partida para decidir qué fichas añadir.
+func crearJugadores(numJugadores int, grupos reglas.ReglasGrupos) []*motor.Jugador {
+	jugadores := make([]*motor.Jugador, 0, numJugadores)
+	for i := 1; i <= numJugadores; i++ {
+		var nombre string
+		if i == 1 {
+			nombre = idioma.T("Tú (Jugador 1)")
+		} else {
+			nombre = fmt.Sprintf("Bot %d", i)
+		}
+		jugador := &motor.Jugador{
+			Nombre:               nombre,
+			Mano:                 make([]mazo.Pieza, 0, 14),
+			HaHechoPrimeraJugada: false, // Inicia en false
+			Estrategia:           asientosConsola[i-1].estrategia,
+		}
+		if bot, ok := jugador.Estrategia.(bots.EstrategiaIntermedio); ok {
+			bot.Grupos = grupos
+			jugador.Estrategia = bot
+		}
+		jugadores = append(jugadores, jugador)
+	}
+	return jugadores
+}
+
+// indicesDeEntrada traduce lo que escribe el jugador a índices de su mano. Cada
+// palabra puede ser un índice ("4") o una ficha en notación corta ("R7"); si hay
+// dos fichas iguales, cada mención usa una distinta.
+func indicesDeEntrada(mano []mazo.Pieza, texto string) ([]int, error) {
+	partes := mazo.SepararEntrada(texto)
+	if len(partes) == 0 {
+		return nil, errors.New(idioma.T("no has seleccionado ninguna ficha"))
+	}
+	usados := make(map[int]bool)
+	indices := make([]int, 0, len(partes))
+	for _, parte := range partes {
+		if indice, err := strconv.Atoi(parte); err == nil {
+			indices = append(indices, indice)
+			continue
+		}
+		ficha, err := mazo.ParsearFicha(parte)
+		if err != nil {
+			return nil, err
+		}
+		encontrada := -1
+		for i, p := range mano {
+			if p.MismoValor(ficha) && !usados[i] {
+				encontrada = i
+				break
+			}
+		}
+		if encontrada < 0 {
+			return nil, fmt.Errorf(idioma.T("no tienes %s en la mano"), ficha.Notacion())
+		}
+		usados[encontrada] = true
+		indices = append(indices, encontrada)
+	}
+	return indices, nil
+}
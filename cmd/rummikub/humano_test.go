@@ -0,0 +1,69 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	"com.github/hapkiduki/rummikub/mazo"
+	"com.github/hapkiduki/rummikub/motor"
+)
+
+// fichasDe lee fichas en notación corta para preparar las pruebas.
+func fichasDe(t testing.TB, notacion string) []mazo.Pieza {
+	t.Helper()
+	fichas, err := mazo.ParsearFichas(notacion)
+	if err != nil {
+		t.Fatalf("notación inválida en la prueba: %v", err)
+	}
+	return fichas
+}
+
+func TestIndicesDeEntrada(t *testing.T) {
+	mano := fichasDe(t, "R7 B5 R8 R7 J R9")
+	casosDePrueba := []struct {
+		entrada  string
+		esperado []int
+		error    bool
+	}{
+		{entrada: "R7 R8 R9", esperado: []int{0, 2, 5}},
+		{entrada: "0, 4, 2", esperado: []int{0, 4, 2}},
+		{entrada: "r7 r7 J", esperado: []int{0, 3, 4}},
+		{entrada: "B5, 5", esperado: []int{1, 5}},
+		{entrada: "R7 R7 R7", error: true},
+		{entrada: "K1", error: true},
+		{entrada: "  ", error: true},
+	}
+	for _, tc := range casosDePrueba {
+		indices, err := indicesDeEntrada(mano, tc.entrada)
+		if (err != nil) != tc.error || !tc.error && !reflect.DeepEqual(indices, tc.esperado) {
+			t.Errorf("Se esperaba %v (error: %v) para %q, pero fue %v (%v)", tc.esperado, tc.error, tc.entrada, indices, err)
+		}
+	}
+}
+
+func TestLineasEstado(t *testing.T) {
+	estado := motor.EstadoPartida{
+		JugadorActual: "Ana",
+		Jugadores:     []motor.EstadoJugador{{Nombre: "Ana", Fichas: 9, HaHechoPrimeraJugada: true}, {Nombre: "Bot 2", Fichas: 14}},
+	}
+	casosDePrueba := []struct {
+		nombre    string
+		marcador  *motor.Marcador
+		esperadas []string
+	}{
+		{
+			nombre:    "Una sola partida",
+			esperadas: []string{"▶ Ana               9 fichas  (ha abierto)", "  Bot 2            14 fichas  (sin abrir)"},
+		},
+		{
+			nombre:    "Serie con puntos",
+			marcador:  &motor.Marcador{Puntos: []int{25, -25}},
+			esperadas: []string{"▶ Ana               9 fichas  (ha abierto)  +25 puntos", "  Bot 2            14 fichas  (sin abrir)  -25 puntos"},
+		},
+	}
+	for _, tc := range casosDePrueba {
+		if lineas := lineasEstado(estado, tc.marcador); !reflect.DeepEqual(lineas, tc.esperadas) {
+			t.Errorf("%s: se esperaba %q, pero fue %q", tc.nombre, tc.esperadas, lineas)
+		}
+	}
+}
@@ -0,0 +1,276 @@
+// Rummikub juega al Rummikub en la terminal, en el navegador o por red, y simula
+// partidas y torneos entre bots.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	"com.github/hapkiduki/rummikub/bots"
+	"com.github/hapkiduki/rummikub/idioma"
+	"com.github/hapkiduki/rummikub/motor"
+	"com.github/hapkiduki/rummikub/perfiles"
+	"com.github/hapkiduki/rummikub/reglas"
+)
+
+func main() {
+	// El idioma del entorno se fija antes de todo para que la ayuda de los flags
+	// ya salga traducida; -idioma lo puede cambiar después.
+	entorno, _ := idioma.Elegir("")
+	idioma.Usar(entorno)
+	// --- SUBCOMANDOS ---
+	if len(os.Args) > 1 && (os.Args[1] == "simular" || os.Args[1] == "simulate") {
+		if err := ejecutarSimulacion(os.Args[2:], os.Stdout); err != nil {
+			fmt.Fprintf(os.Stderr, idioma.T("Error en la simulación: %v\n"), err)
+			os.Exit(2)
+		}
+		return
+	}
+	if len(os.Args) > 1 && (os.Args[1] == "torneo" || os.Args[1] == "tournament") {
+		if err := ejecutarTorneo(os.Args[2:], os.Stdout); err != nil {
+			fmt.Fprintf(os.Stderr, idioma.T("Error en el torneo: %v\n"), err)
+			os.Exit(2)
+		}
+		return
+	}
+	if len(os.Args) > 1 && (os.Args[1] == "probar-bot" || os.Args[1] == "test-bot") {
+		if err := ejecutarProbarBot(os.Args[2:], os.Stdout); err != nil {
+			fmt.Fprintf(os.Stderr, idioma.T("Error al probar el bot: %v\n"), err)
+			os.Exit(1)
+		}
+		return
+	}
+	if len(os.Args) > 1 && (os.Args[1] == "estadisticas" || os.Args[1] == "stats") {
+		if err := ejecutarEstadisticas(os.Args[2:], os.Stdout); err != nil {
+			fmt.Fprintf(os.Stderr, idioma.T("Error al leer las estadísticas: %v\n"), err)
+			os.Exit(1)
+		}
+		return
+	}
+	// --- CONFIGURACIÓN ---
+	direccionServidor := flag.String("servidor", "", idioma.T("modo servidor: dirección TCP donde aceptar jugadores remotos (ej: :9000)"))
+	direccionWeb := flag.String("web", "", idioma.T("modo web: dirección HTTP donde servir la interfaz del navegador (ej: :8080)"))
+	jugadoresServidor := flag.Int("jugadores", 4, idioma.T("número de asientos en modo servidor o web (2-4); los vacíos los ocupan bots"))
+	esperaServidor := flag.Duration("espera", 60*time.Second, idioma.T("tiempo máximo que el servidor espera a jugadores remotos"))
+	tiempoTurno := flag.Duration("tiempo-turno", TiempoTurnoPorDefecto, idioma.T("tiempo que tiene un jugador remoto para cada turno; si se agota, un bot ocupa su asiento (0: sin límite)"))
+	interfaz := flag.String("interfaz", "auto", idioma.T("interfaz del jugador humano: tui (pantalla completa), texto o auto"))
+	pistas := flag.String("pistas", "completa", idioma.T("nivel de las pistas para el jugador humano: no, basica o completa"))
+	robarHastaJugar := flag.Bool("robar-hasta-jugar", false, idioma.T("regla de la casa: quien no juega roba hasta tener algo que jugar"))
+	penalizacion := flag.Int("penalizacion", 0, idioma.T("regla de la casa: fichas que roba quien intenta un movimiento inválido (0: ninguna)"))
+	grupos := flag.String("grupos", "", idioma.T("variantes para tríos y cuartetas, separadas por comas: limitar-comodines, solo-comodines, cerrar-dos-comodines"))
+	penalizarMesa := flag.Bool("penalizar-mesa", false, idioma.T("regla oficial: quien deja la mesa inválida recupera sus fichas y roba 3"))
+	fichasIniciales := flag.Int("fichas-iniciales", motor.FichasPorJugador, idioma.T("regla de la casa: fichas que recibe cada jugador en el reparto (1-26)"))
+	sortearInicio := flag.Bool("sortear-inicio", true, idioma.T("regla oficial: cada jugador saca una ficha y empieza el de la más alta"))
+	rondas := flag.Int("rondas", 1, idioma.T("partidas de la serie contra los bots; se suman los puntos de todas"))
+	cargar := flag.String("cargar", "", idioma.T("sigue una partida guardada con el comando save"))
+	rotarInicio := flag.Bool("rotar-inicio", false, idioma.T("en una serie, empieza cada ronda el siguiente asiento en vez de sortearlo"))
+	lengua := flag.String("idioma", "", idioma.T("idioma de los mensajes: es o en (por defecto, el de LANG)"))
+	archivoRegistro := flagRegistro(flag.CommandLine)
+	archivoEstadisticas := flagEstadisticas(flag.CommandLine)
+	perfil := flag.String("perfil", perfilPorDefecto(), idioma.T("perfil del jugador humano en las estadísticas (vacío: no las guarda)"))
+	flag.Parse()
+	elegido, err := idioma.Elegir(*lengua)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	idioma.Usar(elegido)
+	nivelPistas, err := bots.ParsearNivelPista(*pistas)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	variantes, err := reglas.ParsearGrupos(*grupos)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	if *fichasIniciales < 1 || *fichasIniciales > 26 {
+		fmt.Fprintln(os.Stderr, idioma.T("-fichas-iniciales debe estar entre 1 y 26"))
+		os.Exit(2)
+	}
+	if *rondas < 1 {
+		fmt.Fprintln(os.Stderr, idioma.T("-rondas debe ser al menos 1"))
+		os.Exit(2)
+	}
+	casa := motor.ReglasCasa{
+		RobarHastaPoderJugar:  *robarHastaJugar,
+		PenalizacionInvalida:  *penalizacion,
+		PenalizarMesaInvalida: *penalizarMesa,
+		FichasIniciales:       *fichasIniciales,
+		SortearInicio:         *sortearInicio,
+		Grupos:                variantes,
+	}
+	registro, archivo, err := abrirRegistro(*archivoRegistro)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	defer func() {
+		if err := cerrarRegistro(registro, archivo); err != nil {
+			fmt.Fprintf(os.Stderr, idioma.T("No se pudo escribir el registro: %v\n"), err)
+		}
+	}()
+	if *direccionServidor != "" {
+		servidor, err := NuevoServidor(*direccionServidor, *jugadoresServidor, *esperaServidor)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, idioma.T("No se pudo iniciar el servidor: %v\n"), err)
+			os.Exit(1)
+		}
+		servidor.Reglas = casa
+		servidor.TiempoTurno = *tiempoTurno
+		servidor.Registro = registro
+		if err := servidor.Ejecutar(); err != nil {
+			fmt.Fprintf(os.Stderr, idioma.T("Error en el servidor: %v\n"), err)
+			os.Exit(1)
+		}
+		return
+	}
+	if *direccionWeb != "" {
+		servidorWeb, err := NuevoServidorWeb(*jugadoresServidor, OpcionesWeb{Reglas: casa, Registro: registro})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, idioma.T("No se pudo iniciar el servidor web: %v\n"), err)
+			os.Exit(1)
+		}
+		fmt.Printf(idioma.T("Abre http://%s en tu navegador para jugar.\n"), *direccionWeb)
+		if err := http.ListenAndServe(*direccionWeb, servidorWeb.Handler()); err != nil {
+			fmt.Fprintf(os.Stderr, idioma.T("Error en el servidor web: %v\n"), err)
+			os.Exit(1)
+		}
+		return
+	}
+	var guardada *motor.PartidaGuardada
+	if *cargar != "" {
+		if *rondas > 1 {
+			fmt.Fprintln(os.Stderr, idioma.T("-cargar sigue una sola partida: no se puede usar con -rondas"))
+			os.Exit(2)
+		}
+		if guardada, err = leerPartidaGuardada(*cargar); err != nil {
+			fmt.Fprintf(os.Stderr, idioma.T("No se pudo cargar la partida: %v\n"), err)
+			os.Exit(1)
+		}
+	}
+	fmt.Println(idioma.T("--- ¡Bienvenido a Rummikub en Go! ---"))
+	var jugadores []*motor.Jugador
+	if guardada != nil {
+		// El humano es siempre el primer asiento, que es el que guardó la partida.
+		jugadores = crearJugadores(len(guardada.Jugadores), casa.Grupos)
+		for i, j := range guardada.Jugadores {
+			jugadores[i].Nombre = j.Nombre
+		}
+	} else {
+		jugadores = crearJugadores(obtenerNumeroDeJugadores(), casa.Grupos)
+	}
+	jugadores[0].Estrategia = EstrategiaHumano{Pistas: nivelPistas}
+	var tui *EstrategiaTUI
+	if *interfaz == "tui" || (*interfaz == "auto" && terminalSoportaTUI()) {
+		tui = &EstrategiaTUI{Pistas: nivelPistas}
+		jugadores[0].Estrategia = tui
+	}
+	var almacen *perfiles.Almacen
+	if *perfil != "" && *archivoEstadisticas != "" {
+		if almacen, err = perfiles.Abrir(*archivoEstadisticas); err != nil {
+			fmt.Fprintf(os.Stderr, idioma.T("No se pudieron leer las estadísticas: %v\n"), err)
+		}
+	}
+	marcador := motor.NuevoMarcador(jugadores)
+	var panel *motor.Marcador // Solo se enseñan los puntos si hay más de una ronda.
+	if *rondas > 1 {
+		panel = marcador
+	}
+	primero := 0
+	for ronda := 1; ronda <= *rondas; ronda++ {
+		if *rondas > 1 {
+			fmt.Printf(idioma.T("\n=== Ronda %d de %d ===\n"), ronda, *rondas)
+		}
+		// --- REPARTO ---
+		opciones := motor.OpcionesPartida{Reglas: casa, Observadores: []motor.Observador{marcador}}
+		if *rotarInicio && ronda > 1 {
+			// Las rondas siguientes empiezan en el asiento de la izquierda del anterior.
+			opciones.Reglas.SortearInicio = false
+			opciones.Primero = (primero + 1) % len(jugadores)
+		}
+		if registro != nil {
+			opciones.Observadores = append(opciones.Observadores, registro.Partida(strconv.Itoa(ronda)))
+		}
+		if almacen != nil {
+			opciones.Observadores = append(opciones.Observadores, almacen.Observador(*perfil, 0, rivalesConsola(len(jugadores))))
+		}
+		var partida *motor.Partida
+		if guardada != nil {
+			if partida, err = motor.Cargar(*guardada, jugadores, opciones); err != nil {
+				fmt.Fprintf(os.Stderr, idioma.T("No se pudo cargar la partida: %v\n"), err)
+				os.Exit(1)
+			}
+		} else {
+			for _, j := range jugadores {
+				j.Reiniciar()
+			}
+			partida = motor.NuevaPartida(jugadores, opciones)
+		}
+		primero = partida.Primero
+		if tui != nil {
+			tui.partida, tui.marcador = partida, panel
+		} else {
+			jugadores[0].Estrategia = EstrategiaHumano{Pistas: nivelPistas, partida: partida, historial: &HistorialComandos{}, marcador: panel}
+		}
+		fmt.Println(idioma.T("\n--- ¡Comienza la Partida! ---"))
+		// --- BUCLE PRINCIPAL DEL JUEGO ---
+		partida.Jugar()
+		fmt.Println(idioma.T("\n--- Fin de la Partida ---"))
+		if *rondas > 1 {
+			mostrarMarcador(marcador)
+		}
+	}
+	if almacen != nil {
+		if err := almacen.Err(); err != nil {
+			fmt.Fprintf(os.Stderr, idioma.T("No se pudieron guardar las estadísticas: %v\n"), err)
+		}
+	}
+}
+
+// mostrarMarcador imprime los puntos acumulados de la serie.
+func mostrarMarcador(m *motor.Marcador) {
+	fmt.Printf(idioma.T("\n--- Marcador tras %d ronda(s) ---\n"), m.Rondas)
+	for i, nombre := range m.Jugadores {
+		fmt.Printf(idioma.T("%s: %d puntos\n"), nombre, m.Puntos[i])
+	}
+}
+
+// --- REGISTRO DE EVENTOS ---
+
+// flagRegistro define -registro y su alias -log-file en flags.
+func flagRegistro(flags *flag.FlagSet) *string {
+	ruta := flags.String("registro", "", idioma.T("archivo donde escribir todos los eventos de las partidas en JSON Lines"))
+	flags.StringVar(ruta, "log-file", "", idioma.T("lo mismo que -registro"))
+	return ruta
+}
+
+// abrirRegistro crea el archivo del registro de eventos. Sin ruta no hay registro y
+// devuelve nil.
+func abrirRegistro(ruta string) (*motor.Registro, *os.File, error) {
+	if ruta == "" {
+		return nil, nil, nil
+	}
+	archivo, err := os.Create(ruta)
+	if err != nil {
+		return nil, nil, err
+	}
+	return motor.NuevoRegistro(archivo), archivo, nil
+}
+
+// cerrarRegistro cierra el archivo y devuelve el primer error al escribirlo.
+func cerrarRegistro(registro *motor.Registro, archivo *os.File) error {
+	if registro == nil {
+		return nil
+	}
+	err := registro.Err()
+	if errCerrar := archivo.Close(); err == nil {
+		err = errCerrar
+	}
+	return err
+}
@@ -0,0 +1,379 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"sync"
+	"time"
+
+	"com.github/hapkiduki/rummikub/bots"
+	"com.github/hapkiduki/rummikub/idioma"
+	"com.github/hapkiduki/rummikub/mazo"
+	"com.github/hapkiduki/rummikub/motor"
+)
+
+// --- MODO SERVIDOR (JUEGO EN RED) ---
+//
+// Protocolo: cada mensaje es un objeto JSON en una sola línea terminada en '\n'.
+// Todos los mensajes tienen un campo "tipo".
+//
+// Cliente → servidor:
+//
+//	{"tipo":"unirse","nombre":"Ana"}
+//	{"tipo":"jugada","movimiento":{"accion":"jugar","indices":[0,4,8]}}
+//	{"tipo":"jugada","movimiento":{"accion":"anadir","indices":[3],"jugada":1}}
+//	{"tipo":"jugada","movimiento":{"accion":"robar"}}
+//
+// Servidor → cliente:
+//
+//	{"tipo":"bienvenida","asiento":1,"nombre":"Ana"}  asientos numerados desde 1
+//	{"tipo":"estado","estado":{...}}    tras cada turno, con la mano del destinatario
+//	{"tipo":"tu_turno","estado":{...}}  el servidor espera un mensaje "jugada"
+//	{"tipo":"error","error":"..."}      movimiento rechazado o mensaje inesperado
+//	{"tipo":"fin","estado":{...}}       la partida ha terminado; se cierra la conexión
+//
+// Los índices de "indices" se refieren a la mano tal como aparece en el último estado
+// recibido. Tras un "error" en tu turno el servidor sigue esperando otra jugada. Quien
+// no responde en Servidor.TiempoTurno pierde la conexión y un bot ocupa su asiento.
+
+// Mensaje es la unidad del protocolo de red.
+type Mensaje struct {
+	Tipo       string               `json:"tipo"`
+	Nombre     string               `json:"nombre,omitempty"`
+	Asiento    int                  `json:"asiento,omitempty"`
+	Movimiento *motor.Movimiento    `json:"movimiento,omitempty"`
+	Estado     *motor.EstadoPartida `json:"estado,omitempty"`
+	Error      string               `json:"error,omitempty"`
+}
+
+// clienteRemoto es la conexión de un jugador remoto.
+type clienteRemoto struct {
+	conn      net.Conn
+	nombre    string
+	entrantes chan recibido
+	mu        sync.Mutex // protege las escrituras, enTurno, turnos y err
+	enTurno   bool
+	turnos    int   // Turnos empezados; identifica el turno en curso.
+	err       error // Por qué dejó de leer, una vez cerrado entrantes.
+}
+
+// recibido es un mensaje del cliente junto con el turno en el que llegó.
+type recibido struct {
+	Mensaje
+	turno int
+}
+
+func (c *clienteRemoto) enviar(m Mensaje) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	datos, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+	c.conn.SetWriteDeadline(time.Now().Add(10 * time.Second))
+	_, err = c.conn.Write(append(datos, '\n'))
+	return err
+}
+
+// leer recibe los mensajes del cliente. Los que llegan fuera de su turno se rechazan
+// aquí mismo; los demás se entregan al motor a través de entrantes.
+func (c *clienteRemoto) leer(scanner *bufio.Scanner) {
+	defer close(c.entrantes)
+	defer func() {
+		c.mu.Lock()
+		c.err = scanner.Err()
+		c.mu.Unlock()
+	}()
+	for scanner.Scan() {
+		var m Mensaje
+		if err := json.Unmarshal(scanner.Bytes(), &m); err != nil {
+			c.enviar(Mensaje{Tipo: "error", Error: idioma.Tf("mensaje mal formado: %v", err)})
+			continue
+		}
+		c.mu.Lock()
+		enTurno, turno := c.enTurno, c.turnos
+		c.mu.Unlock()
+		if !enTurno {
+			c.enviar(Mensaje{Tipo: "error", Error: idioma.T("no es tu turno")})
+			continue
+		}
+		c.entrantes <- recibido{Mensaje: m, turno: turno}
+	}
+}
+
+// marcarTurno abre o cierra el turno del cliente. Cada turno abierto tiene su propio
+// número, para descartar lo que llegó durante el anterior.
+func (c *clienteRemoto) marcarTurno(enTurno bool) {
+	c.mu.Lock()
+	c.enTurno = enTurno
+	if enTurno {
+		c.turnos++
+	}
+	c.mu.Unlock()
+}
+
+// tiempoAgotado indica si el cliente dejó de leerse por agotar el plazo de su turno.
+func (c *clienteRemoto) tiempoAgotado() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return errors.Is(c.err, os.ErrDeadlineExceeded)
+}
+
+// turnoActual devuelve el número del turno en curso.
+func (c *clienteRemoto) turnoActual() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.turnos
+}
+
+// --- ESTRATEGIA: JUGADOR REMOTO
+
+// EstrategiaRemota delega las decisiones en un cliente conectado por red.
+// Si el cliente se desconecta o agota su tiempo, un bot intermedio ocupa su asiento.
+type EstrategiaRemota struct {
+	cliente *clienteRemoto
+	partida *motor.Partida
+	tiempo  time.Duration // Plazo para cada turno; 0 espera sin límite.
+}
+
+func (e *EstrategiaRemota) JugarTurno(jugador *motor.Jugador, mesa [][]mazo.Pieza) [][]mazo.Pieza {
+	e.cliente.marcarTurno(true)
+	defer e.cliente.marcarTurno(false)
+	if e.tiempo > 0 {
+		// Al vencer el plazo falla la lectura, se cierra entrantes y juega un bot.
+		e.cliente.conn.SetReadDeadline(time.Now().Add(e.tiempo))
+		defer e.cliente.conn.SetReadDeadline(time.Time{})
+	}
+	estado := e.partida.Estado(jugador)
+	if err := e.cliente.enviar(Mensaje{Tipo: "tu_turno", Estado: &estado}); err != nil {
+		return e.sustituirPorBot(jugador, mesa)
+	}
+	turno := e.cliente.turnoActual()
+	for m := range e.cliente.entrantes {
+		if m.turno != turno {
+			// Llegó en un turno anterior, después de aceptar su jugada: sus índices ya no
+			// valen para la mano actual.
+			e.cliente.enviar(Mensaje{Tipo: "error", Error: idioma.T("no es tu turno")})
+			continue
+		}
+		if m.Tipo != "jugada" || m.Movimiento == nil {
+			e.cliente.enviar(Mensaje{Tipo: "error", Error: idioma.T("se esperaba un mensaje de tipo 'jugada'")})
+			continue
+		}
+		var err error
+		mesa, err = e.partida.Reglas.AplicarMovimiento(jugador, mesa, *m.Movimiento)
+		if err != nil {
+			e.cliente.enviar(Mensaje{Tipo: "error", Error: err.Error()})
+			if e.partida.IntentoInvalido(jugador) {
+				return mesa
+			}
+			continue
+		}
+		return mesa
+	}
+	return e.sustituirPorBot(jugador, mesa)
+}
+
+// sustituirPorBot cierra la conexión, avisa a los observadores y deja que un bot
+// juegue este turno y los siguientes.
+func (e *EstrategiaRemota) sustituirPorBot(jugador *motor.Jugador, mesa [][]mazo.Pieza) [][]mazo.Pieza {
+	tiempoAgotado := e.cliente.tiempoAgotado()
+	if tiempoAgotado {
+		e.cliente.enviar(Mensaje{Tipo: "error", Error: idioma.T("se te ha acabado el tiempo del turno: un bot ocupa tu asiento")})
+	}
+	e.cliente.conn.Close()
+	e.partida.Emitir(motor.JugadorSustituido{Turno: e.partida.Turno, Jugador: jugador.Nombre, TiempoAgotado: tiempoAgotado})
+	jugador.Estrategia = bots.EstrategiaIntermedio{Silencioso: true, Grupos: e.partida.Reglas.Grupos}
+	return jugador.Estrategia.JugarTurno(jugador, mesa)
+}
+
+// TiempoTurnoPorDefecto es lo que tiene un jugador remoto para responder en su turno.
+const TiempoTurnoPorDefecto = 2 * time.Minute
+
+// Servidor acepta jugadores remotos por TCP y dirige una partida entre ellos.
+type Servidor struct {
+	Reglas       motor.ReglasCasa // Reglas de la casa de la partida; se pueden cambiar antes de Ejecutar.
+	Registro     *motor.Registro  // Si no es nil, recibe los eventos de la partida.
+	TiempoTurno  time.Duration    // Plazo de cada turno remoto; 0 espera sin límite.
+	listener     net.Listener
+	numJugadores int
+	espera       time.Duration
+}
+
+// NuevoServidor abre la dirección indicada. Los asientos que no se llenen
+// antes de que pase espera los ocupan bots.
+func NuevoServidor(direccion string, numJugadores int, espera time.Duration) (*Servidor, error) {
+	if numJugadores < 2 || numJugadores > 4 {
+		return nil, fmt.Errorf(idioma.T("número de jugadores inválido: %d (debe estar entre 2 y 4)"), numJugadores)
+	}
+	listener, err := net.Listen("tcp", direccion)
+	if err != nil {
+		return nil, err
+	}
+	return &Servidor{listener: listener, numJugadores: numJugadores, espera: espera, TiempoTurno: TiempoTurnoPorDefecto}, nil
+}
+
+// Direccion devuelve la dirección en la que escucha el servidor.
+func (s *Servidor) Direccion() string {
+	return s.listener.Addr().String()
+}
+
+// Ejecutar espera a los jugadores, juega una partida completa y cierra las conexiones.
+func (s *Servidor) Ejecutar() error {
+	fmt.Printf(idioma.T("Servidor de Rummikub escuchando en %s. Esperando hasta %d jugadores...\n"), s.Direccion(), s.numJugadores)
+	clientes := s.aceptarClientes()
+	defer func() {
+		for _, c := range clientes {
+			c.conn.Close()
+		}
+	}()
+
+	jugadores := make([]*motor.Jugador, 0, s.numJugadores)
+	remotas := make([]*EstrategiaRemota, 0, len(clientes))
+	for _, c := range clientes {
+		estrategia := &EstrategiaRemota{cliente: c, tiempo: s.TiempoTurno}
+		remotas = append(remotas, estrategia)
+		jugadores = append(jugadores, &motor.Jugador{Nombre: c.nombre, Mano: make([]mazo.Pieza, 0, 14), Estrategia: estrategia})
+	}
+	rivales := []motor.Estrategia{bots.EstrategiaIntermedio{Grupos: s.Reglas.Grupos}, bots.EstrategiaNovato{}}
+	for i := len(jugadores); i < s.numJugadores; i++ {
+		jugadores = append(jugadores, &motor.Jugador{
+			Nombre:     nombreBot(i),
+			Mano:       make([]mazo.Pieza, 0, 14),
+			Estrategia: rivales[i%len(rivales)],
+		})
+	}
+
+	opciones := motor.OpcionesPartida{Reglas: s.Reglas}
+	if s.Registro != nil {
+		opciones.Observadores = append(opciones.Observadores, s.Registro.Partida("1"))
+	}
+	partida := motor.NuevaPartida(jugadores, opciones)
+	for _, r := range remotas {
+		r.partida = partida
+	}
+	fmt.Println(idioma.T("\n--- ¡Comienza la Partida! ---"))
+	s.difundir(partida, jugadores, "estado")
+	for !partida.Terminada {
+		partida.JugarTurno()
+		s.difundir(partida, jugadores, "estado")
+	}
+	s.difundir(partida, jugadores, "fin")
+	fmt.Println(idioma.T("\n--- Fin de la Partida ---"))
+	return nil
+}
+
+// aceptarClientes recibe conexiones hasta llenar los asientos o agotar la espera.
+func (s *Servidor) aceptarClientes() []*clienteRemoto {
+	nuevos := make(chan *clienteRemoto)
+	var wg sync.WaitGroup
+	go func() {
+		for {
+			conn, err := s.listener.Accept()
+			if err != nil {
+				return // El listener se cerró: ya no se aceptan jugadores.
+			}
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if c := s.saludar(conn); c != nil {
+					nuevos <- c
+				}
+			}()
+		}
+	}()
+
+	clientes := make([]*clienteRemoto, 0, s.numJugadores)
+	limite := time.After(s.espera)
+esperar:
+	for len(clientes) < s.numJugadores {
+		select {
+		case c := <-nuevos:
+			if !s.nombreLibre(c.nombre, clientes) {
+				// Los eventos, el marcador y las estadísticas identifican a los jugadores por su nombre.
+				c.enviar(Mensaje{Tipo: "error", Error: idioma.Tf("el nombre %q ya está en uso", c.nombre)})
+				c.conn.Close()
+				continue
+			}
+			clientes = append(clientes, c)
+			fmt.Printf(idioma.T("%s se ha unido a la partida (%d/%d).\n"), c.nombre, len(clientes), s.numJugadores)
+		case <-limite:
+			fmt.Println(idioma.T("Se acabó el tiempo de espera. Los asientos libres los ocuparán bots."))
+			break esperar
+		}
+	}
+	s.listener.Close()
+	// Los saludos que estuvieran en curso se rechazan: la partida ya está completa.
+	go func() {
+		for c := range nuevos {
+			c.enviar(Mensaje{Tipo: "error", Error: idioma.T("la partida ya ha comenzado")})
+			c.conn.Close()
+		}
+	}()
+	go func() {
+		wg.Wait()
+		close(nuevos)
+	}()
+	for i, c := range clientes {
+		c.enviar(Mensaje{Tipo: "bienvenida", Asiento: i + 1, Nombre: c.nombre})
+	}
+	return clientes
+}
+
+// nombreBot es el nombre del bot que ocupa el asiento indicado (desde 0).
+func nombreBot(asiento int) string {
+	return fmt.Sprintf("Bot %d", asiento+1)
+}
+
+// nombreLibre dice si ningún jugador ya unido ni ningún bot que pueda ocupar un asiento
+// libre se llama así.
+func (s *Servidor) nombreLibre(nombre string, clientes []*clienteRemoto) bool {
+	for _, c := range clientes {
+		if c.nombre == nombre {
+			return false
+		}
+	}
+	for i := 0; i < s.numJugadores; i++ {
+		if nombreBot(i) == nombre {
+			return false
+		}
+	}
+	return true
+}
+
+// saludar espera el mensaje "unirse" de una conexión nueva.
+func (s *Servidor) saludar(conn net.Conn) *clienteRemoto {
+	conn.SetReadDeadline(time.Now().Add(s.espera))
+	scanner := bufio.NewScanner(conn)
+	if !scanner.Scan() {
+		conn.Close()
+		return nil
+	}
+	var m Mensaje
+	if err := json.Unmarshal(scanner.Bytes(), &m); err != nil || m.Tipo != "unirse" || m.Nombre == "" {
+		c := &clienteRemoto{conn: conn}
+		c.enviar(Mensaje{Tipo: "error", Error: idioma.T("el primer mensaje debe ser {\"tipo\":\"unirse\",\"nombre\":\"...\"}")})
+		conn.Close()
+		return nil
+	}
+	conn.SetReadDeadline(time.Time{})
+	c := &clienteRemoto{conn: conn, nombre: m.Nombre, entrantes: make(chan recibido)}
+	go c.leer(scanner)
+	return c
+}
+
+// difundir envía a cada jugador remoto el estado de la partida con su propia mano.
+func (s *Servidor) difundir(partida *motor.Partida, jugadores []*motor.Jugador, tipo string) {
+	for _, j := range jugadores {
+		remota, ok := j.Estrategia.(*EstrategiaRemota)
+		if !ok {
+			continue
+		}
+		estado := partida.Estado(j)
+		remota.cliente.enviar(Mensaje{Tipo: tipo, Estado: &estado})
+	}
+}
@@ -0,0 +1,157 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"com.github/hapkiduki/rummikub/bots"
+	"com.github/hapkiduki/rummikub/motor"
+)
+
+// clientePrueba se conecta al servidor y roba en cada turno. En su primer turno
+// manda antes una jugada inválida para comprobar que el servidor la rechaza.
+func clientePrueba(direccion, nombre string) (fin *motor.EstadoPartida, errores int, err error) {
+	conn, err := net.Dial("tcp", direccion)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(30 * time.Second))
+	codificador := json.NewEncoder(conn)
+	scanner := bufio.NewScanner(conn)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	if err := codificador.Encode(Mensaje{Tipo: "unirse", Nombre: nombre}); err != nil {
+		return nil, 0, err
+	}
+	primerTurno := true
+	for scanner.Scan() {
+		var m Mensaje
+		if err := json.Unmarshal(scanner.Bytes(), &m); err != nil {
+			return nil, errores, err
+		}
+		switch m.Tipo {
+		case "tu_turno":
+			if primerTurno {
+				primerTurno = false
+				// Una sola ficha nunca es una jugada válida.
+				codificador.Encode(Mensaje{Tipo: "jugada", Movimiento: &motor.Movimiento{Accion: motor.MovimientoJugar, Indices: []int{0}}})
+			}
+			codificador.Encode(Mensaje{Tipo: "jugada", Movimiento: &motor.Movimiento{Accion: motor.MovimientoRobar}})
+		case "error":
+			errores++
+		case "fin":
+			return m.Estado, errores, nil
+		}
+	}
+	return nil, errores, fmt.Errorf("conexión cerrada sin mensaje de fin")
+}
+
+func TestServidorPartidaEntreClientesRemotos(t *testing.T) {
+	servidor, err := NuevoServidor("127.0.0.1:0", 2, 5*time.Second)
+	if err != nil {
+		t.Fatalf("No se pudo iniciar el servidor: %v", err)
+	}
+	terminado := make(chan error, 1)
+	go func() { terminado <- servidor.Ejecutar() }()
+
+	type resultado struct {
+		fin     *motor.EstadoPartida
+		errores int
+		err     error
+	}
+	resultados := make(chan resultado, 2)
+	for _, nombre := range []string{"Ana", "Luis"} {
+		go func(nombre string) {
+			fin, errores, err := clientePrueba(servidor.Direccion(), nombre)
+			resultados <- resultado{fin, errores, err}
+		}(nombre)
+	}
+
+	for i := 0; i < 2; i++ {
+		r := <-resultados
+		if r.err != nil {
+			t.Fatalf("Error en el cliente: %v", r.err)
+		}
+		if !r.fin.Terminada || r.fin.Ganador == "" {
+			t.Errorf("Se esperaba una partida terminada con ganador, se obtuvo %+v", r.fin)
+		}
+		if r.fin.FichasEnMazo != 0 {
+			t.Errorf("Los clientes solo roban, se esperaba el mazo vacío y quedan %d fichas", r.fin.FichasEnMazo)
+		}
+		if r.errores != 1 {
+			t.Errorf("Se esperaba 1 error por la jugada inválida, se obtuvieron %d", r.errores)
+		}
+	}
+	if err := <-terminado; err != nil {
+		t.Errorf("El servidor terminó con error: %v", err)
+	}
+}
+
+func TestJugadaDeUnTurnoAnterior(t *testing.T) {
+	conexion, otroExtremo := net.Pipe()
+	defer conexion.Close()
+	go io.Copy(io.Discard, otroExtremo)
+	cliente := &clienteRemoto{conn: conexion, entrantes: make(chan recibido, 2)}
+	remota := &EstrategiaRemota{cliente: cliente}
+	ana := &motor.Jugador{Nombre: "Ana", Estrategia: remota}
+	remota.partida = motor.NuevaPartida([]*motor.Jugador{ana, {Nombre: "Luis"}}, motor.OpcionesPartida{Silenciosa: true})
+	ana.Mano = fichasDe(t, "R10 R11 R12")
+
+	// La jugada llegó cuando el turno anterior ya había terminado: no debe aplicarse en este.
+	cliente.entrantes <- recibido{Mensaje: Mensaje{Tipo: "jugada", Movimiento: &motor.Movimiento{Accion: motor.MovimientoJugar, Indices: []int{0, 1, 2}}}, turno: 0}
+	cliente.entrantes <- recibido{Mensaje: Mensaje{Tipo: "jugada", Movimiento: &motor.Movimiento{Accion: motor.MovimientoRobar}}, turno: 1}
+	if mesa := remota.JugarTurno(ana, nil); len(mesa) != 0 || len(ana.Mano) != 3 {
+		t.Errorf("Se esperaba descartar la jugada del turno anterior, pero la mesa es %v", mesa)
+	}
+}
+
+func TestTiempoDeTurnoAgotado(t *testing.T) {
+	conexion, otroExtremo := net.Pipe()
+	defer otroExtremo.Close()
+	go io.Copy(io.Discard, otroExtremo) // El cliente lee pero nunca responde.
+	cliente := &clienteRemoto{conn: conexion, entrantes: make(chan recibido)}
+	go cliente.leer(bufio.NewScanner(conexion))
+	remota := &EstrategiaRemota{cliente: cliente, tiempo: 50 * time.Millisecond}
+	ana := &motor.Jugador{Nombre: "Ana", Estrategia: remota}
+	var sustituido *motor.JugadorSustituido
+	remota.partida = motor.NuevaPartida([]*motor.Jugador{ana, {Nombre: "Luis"}}, motor.OpcionesPartida{
+		Silenciosa: true,
+		Observadores: []motor.Observador{motor.ObservadorFunc(func(_ *motor.Partida, e motor.Evento) {
+			if s, ok := e.(motor.JugadorSustituido); ok {
+				sustituido = &s
+			}
+		})},
+	})
+
+	remota.JugarTurno(ana, nil)
+	if sustituido == nil || !sustituido.TiempoAgotado || sustituido.Jugador != "Ana" {
+		t.Errorf("Se esperaba que un bot sustituyera a Ana por agotar su tiempo, pero el evento fue %+v", sustituido)
+	}
+	if _, ok := ana.Estrategia.(bots.EstrategiaIntermedio); !ok {
+		t.Errorf("Se esperaba un bot intermedio en el asiento de Ana, pero juega %T", ana.Estrategia)
+	}
+}
+
+func TestNombreLibre(t *testing.T) {
+	servidor := &Servidor{numJugadores: 3}
+	clientes := []*clienteRemoto{{nombre: "Ana"}}
+	casosDePrueba := []struct {
+		nombre string
+		libre  bool
+	}{
+		{nombre: "Luis", libre: true},
+		{nombre: "Ana", libre: false},
+		{nombre: "Bot 3", libre: false},
+		{nombre: "Bot 4", libre: true},
+	}
+	for _, tc := range casosDePrueba {
+		if libre := servidor.nombreLibre(tc.nombre, clientes); libre != tc.libre {
+			t.Errorf("Se esperaba que %q estuviera libre: %v, pero fue %v", tc.nombre, tc.libre, libre)
+		}
+	}
+}
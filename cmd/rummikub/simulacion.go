@@ -0,0 +1,228 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"math/rand"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"com.github/hapkiduki/rummikub/bots"
+	"com.github/hapkiduki/rummikub/externo"
+	"com.github/hapkiduki/rummikub/idioma"
+	"com.github/hapkiduki/rummikub/mazo"
+	"com.github/hapkiduki/rummikub/motor"
+	"com.github/hapkiduki/rummikub/reglas"
+)
+
+// --- SIMULACIÓN DE PARTIDAS ENTRE BOTS ---
+
+// ConfigSimulacion describe una tanda de partidas entre bots.
+type ConfigSimulacion struct {
+	Estrategias []string // Un participante por asiento, por nombre registrado.
+	Partidas    int
+	Paralelo    int             // Número de goroutines que juegan a la vez.
+	Semilla     int64           // La partida i se baraja con Semilla+i.
+	Registro    *motor.Registro // Si no es nil, recibe los eventos de todas las partidas.
+}
+
+// resultadoPartida resume una partida simulada desde el punto de vista de los participantes.
+type resultadoPartida struct {
+	ganador     int   // Índice del participante ganador en ConfigSimulacion.Estrategias.
+	turnos      int   // Turnos jugados hasta el final.
+	puntos      []int // Puntos que le quedaron en la mano a cada participante.
+	mazoAgotado bool  // true si la partida acabó por quedarse sin fichas en el mazo.
+}
+
+// ResumenSimulacion acumula los resultados de todas las partidas de una simulación.
+type ResumenSimulacion struct {
+	Estrategias   []string
+	Partidas      int
+	Victorias     []int
+	PuntosEnMano  []int // Suma de los puntos que le quedaron a cada participante.
+	TurnosTotales int
+	MazoAgotado   int
+}
+
+// jugarPartidaSimulada juega una partida completa sin narración y, si hay registro,
+// anota sus eventos con el identificador del trabajo.
+func jugarPartidaSimulada(t trabajoPartida, registro *motor.Registro) resultadoPartida {
+	estrategias := t.estrategias
+	jugadores := make([]*motor.Jugador, len(t.orden))
+	participanteDe := make(map[*motor.Jugador]int, len(t.orden))
+	for asiento, participante := range t.orden {
+		estrategia, _ := bots.Crear(estrategias[participante])
+		jugador := &motor.Jugador{
+			Nombre:     fmt.Sprintf("%d:%s", participante+1, estrategias[participante]),
+			Mano:       make([]mazo.Pieza, 0, 14),
+			Estrategia: estrategia,
+		}
+		jugadores[asiento] = jugador
+		participanteDe[jugador] = participante
+	}
+	opciones := motor.OpcionesPartida{Rand: rand.New(rand.NewSource(t.semilla)), Silenciosa: true}
+	if registro != nil {
+		opciones.Observadores = append(opciones.Observadores, registro.Partida(t.id))
+	}
+	partida := motor.NuevaPartida(jugadores, opciones)
+	for _, j := range jugadores {
+		if e, ok := j.Estrategia.(*externo.Estrategia); ok {
+			e.Partida = partida
+			defer e.Cerrar()
+		}
+	}
+	partida.Jugar()
+
+	resultado := resultadoPartida{
+		ganador:     participanteDe[partida.Ganador],
+		turnos:      partida.Turno,
+		puntos:      make([]int, len(estrategias)),
+		mazoAgotado: partida.MazoAgotado,
+	}
+	for _, j := range jugadores {
+		resultado.puntos[participanteDe[j]] = reglas.CalcularPuntosMano(j.Mano)
+	}
+	return resultado
+}
+
+// trabajoPartida es una partida pendiente de jugar en jugarPartidasSimuladas.
+// orden[asiento] es el índice en estrategias del participante que se sienta en cada asiento.
+type trabajoPartida struct {
+	id          string // Identifica la partida en el registro de eventos.
+	estrategias []string
+	orden       []int
+	semilla     int64
+}
+
+// jugarPartidasSimuladas reparte las partidas entre varias goroutines y devuelve los
+// resultados en el mismo orden que los trabajos.
+func jugarPartidasSimuladas(trabajos []trabajoPartida, paralelo int, registro *motor.Registro) []resultadoPartida {
+	if paralelo < 1 {
+		paralelo = 1
+	}
+	resultados := make([]resultadoPartida, len(trabajos))
+	indices := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < paralelo; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indices {
+				resultados[i] = jugarPartidaSimulada(trabajos[i], registro)
+			}
+		}()
+	}
+	for i := range trabajos {
+		indices <- i
+	}
+	close(indices)
+	wg.Wait()
+	return resultados
+}
+
+// validarEstrategias comprueba que todos los nombres estén registrados.
+func validarEstrategias(nombres []string) error {
+	for _, nombre := range nombres {
+		if _, ok := bots.Crear(nombre); !ok {
+			return fmt.Errorf(idioma.T("estrategia desconocida '%s' (disponibles: %s)"), nombre, strings.Join(bots.Nombres(), ", "))
+		}
+	}
+	return nil
+}
+
+// simular juega todas las partidas de la configuración repartidas entre varias goroutines.
+// Los participantes rotan de asiento en cada partida para que nadie empiece siempre primero.
+func simular(cfg ConfigSimulacion) (ResumenSimulacion, error) {
+	n := len(cfg.Estrategias)
+	if n < 2 || n > 4 {
+		return ResumenSimulacion{}, fmt.Errorf(idioma.T("se necesitan entre 2 y 4 estrategias, hay %d"), n)
+	}
+	if err := validarEstrategias(cfg.Estrategias); err != nil {
+		return ResumenSimulacion{}, err
+	}
+	if cfg.Partidas < 1 {
+		return ResumenSimulacion{}, errors.New(idioma.T("el número de partidas debe ser positivo"))
+	}
+
+	trabajos := make([]trabajoPartida, cfg.Partidas)
+	for i := range trabajos {
+		orden := make([]int, n)
+		for asiento := range orden {
+			orden[asiento] = (asiento + i) % n
+		}
+		trabajos[i] = trabajoPartida{id: strconv.Itoa(i + 1), estrategias: cfg.Estrategias, orden: orden, semilla: cfg.Semilla + int64(i)}
+	}
+
+	resumen := ResumenSimulacion{
+		Estrategias:  cfg.Estrategias,
+		Victorias:    make([]int, n),
+		PuntosEnMano: make([]int, n),
+	}
+	for _, r := range jugarPartidasSimuladas(trabajos, cfg.Paralelo, cfg.Registro) {
+		resumen.Partidas++
+		resumen.Victorias[r.ganador]++
+		resumen.TurnosTotales += r.turnos
+		if r.mazoAgotado {
+			resumen.MazoAgotado++
+		}
+		for i, puntos := range r.puntos {
+			resumen.PuntosEnMano[i] += puntos
+		}
+	}
+	return resumen, nil
+}
+
+// Imprimir escribe el resumen como una tabla.
+func (r ResumenSimulacion) Imprimir(w io.Writer) {
+	partidas := float64(r.Partidas)
+	fmt.Fprintf(w, idioma.T("Partidas jugadas: %d\n"), r.Partidas)
+	fmt.Fprintf(w, idioma.T("Duración media: %.1f turnos\n"), float64(r.TurnosTotales)/partidas)
+	fmt.Fprintf(w, idioma.T("Mazo agotado: %d partidas (%.1f%%)\n\n"), r.MazoAgotado, 100*float64(r.MazoAgotado)/partidas)
+	fmt.Fprintf(w, "%-16s %10s %12s %16s\n", idioma.T("Participante"), idioma.T("Victorias"), idioma.T("% victorias"), idioma.T("Puntos en mano"))
+	for i, nombre := range r.Estrategias {
+		fmt.Fprintf(w, "%-16s %10d %11.1f%% %16.1f\n",
+			fmt.Sprintf("%d:%s", i+1, nombre), r.Victorias[i],
+			100*float64(r.Victorias[i])/partidas, float64(r.PuntosEnMano[i])/partidas)
+	}
+}
+
+// ejecutarSimulacion implementa el subcomando "simular".
+func ejecutarSimulacion(args []string, salida io.Writer) error {
+	flags := flag.NewFlagSet("simular", flag.ContinueOnError)
+	partidas := flags.Int("partidas", 1000, idioma.T("número de partidas a jugar"))
+	estrategias := flags.String("estrategias", "novato,intermedio", idioma.T("estrategias separadas por comas, una por asiento (2-4): ")+strings.Join(bots.Nombres(), ", "))
+	paralelo := flags.Int("paralelo", runtime.NumCPU(), idioma.T("partidas que se juegan a la vez"))
+	semilla := flags.Int64("semilla", 0, idioma.T("semilla para barajar; con la misma semilla se repiten las mismas partidas (0: aleatoria)"))
+	flags.Var(botsExternos{}, "externo", idioma.T("registra un programa externo como estrategia: nombre=comando (se puede repetir)"))
+	archivoRegistro := flagRegistro(flags)
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *semilla == 0 {
+		*semilla = time.Now().UnixNano()
+	}
+	nombres := strings.Split(*estrategias, ",")
+	for i := range nombres {
+		nombres[i] = strings.TrimSpace(nombres[i])
+	}
+	registro, archivo, err := abrirRegistro(*archivoRegistro)
+	if err != nil {
+		return err
+	}
+	inicio := time.Now()
+	resumen, err := simular(ConfigSimulacion{Estrategias: nombres, Partidas: *partidas, Paralelo: *paralelo, Semilla: *semilla, Registro: registro})
+	if err := cerrarRegistro(registro, archivo); err != nil {
+		return err
+	}
+	if err != nil {
+		return err
+	}
+	resumen.Imprimir(salida)
+	fmt.Fprintf(salida, idioma.T("\nSemilla: %d · tiempo: %s\n"), *semilla, time.Since(inicio).Round(time.Millisecond))
+	return nil
+}
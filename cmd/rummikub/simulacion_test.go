@@ -0,0 +1,41 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSimularEsReproducible(t *testing.T) {
+	cfg := ConfigSimulacion{Estrategias: []string{"novato", "intermedio", "novato"}, Partidas: 30, Paralelo: 1, Semilla: 42}
+	resumen, err := simular(cfg)
+	if err != nil {
+		t.Fatalf("Error inesperado: %v", err)
+	}
+	victorias := 0
+	for _, v := range resumen.Victorias {
+		victorias += v
+	}
+	if resumen.Partidas != 30 || victorias != 30 {
+		t.Errorf("Se esperaban 30 partidas con un ganador cada una, hay %d partidas y %d victorias", resumen.Partidas, victorias)
+	}
+	if resumen.TurnosTotales <= 0 {
+		t.Errorf("Se esperaban turnos jugados, se obtuvieron %d", resumen.TurnosTotales)
+	}
+
+	// Con la misma semilla el resultado no depende de cuántas goroutines jueguen.
+	cfg.Paralelo = 4
+	enParalelo, err := simular(cfg)
+	if err != nil {
+		t.Fatalf("Error inesperado: %v", err)
+	}
+	if !reflect.DeepEqual(resumen, enParalelo) {
+		t.Errorf("Con la misma semilla se esperaba el mismo resumen:\n%+v\n%+v", resumen, enParalelo)
+	}
+}
+
+func TestSimularEstrategiaDesconocida(t *testing.T) {
+	_, err := simular(ConfigSimulacion{Estrategias: []string{"novato", "experto"}, Partidas: 1})
+	if err == nil {
+		t.Error("Se esperaba un error por la estrategia desconocida")
+	}
+}
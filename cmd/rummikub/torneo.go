@@ -0,0 +1,391 @@
+package main
+
+import (
+	"encoding/csv"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"com.github/hapkiduki/rummikub/bots"
+	"com.github/hapkiduki/rummikub/idioma"
+	"com.github/hapkiduki/rummikub/motor"
+)
+
+// --- TORNEOS ENTRE ESTRATEGIAS ---
+//
+// Cada encuentro entre un grupo de estrategias se juega con las mismas semillas en
+// todas las permutaciones de asientos: cada estrategia recibe exactamente las mismas
+// manos que sus rivales y empieza en cada asiento el mismo número de veces.
+
+const (
+	eloInicial = 1500.0
+	eloK       = 16.0
+)
+
+// ConfigTorneo describe un torneo entre estrategias registradas.
+type ConfigTorneo struct {
+	Estrategias []string
+	Formato     string // "liga" (todos contra todos) o "suizo".
+	PorMesa     int    // Jugadores por partida (2-4).
+	Repartos    int    // Semillas distintas por encuentro; cada una se juega en todas las permutaciones.
+	Rondas      int    // Solo en formato suizo.
+	Paralelo    int
+	Semilla     int64
+	Registro    *motor.Registro // Si no es nil, recibe los eventos de todas las partidas.
+}
+
+// PartidaTorneo es una partida jugada en el torneo, tal como se exporta al CSV.
+type PartidaTorneo struct {
+	Ronda       int
+	Semilla     int64
+	Asientos    []string // Estrategia sentada en cada asiento.
+	Ganador     string
+	Turnos      int
+	MazoAgotado bool
+	Puntos      []int // Puntos en mano al final, por asiento.
+}
+
+// ClasificacionTorneo es la fila de una estrategia en la tabla final.
+type ClasificacionTorneo struct {
+	Estrategia string
+	Partidas   int
+	Victorias  int
+	Puntos     float64 // Puntos de encuentro: la parte de las victorias de cada encuentro (1 por descanso).
+	Elo        float64
+}
+
+// ResultadoTorneo reúne la clasificación y todas las partidas jugadas.
+type ResultadoTorneo struct {
+	Formato       string
+	Clasificacion []ClasificacionTorneo
+	Partidas      []PartidaTorneo
+}
+
+// permutaciones devuelve todas las ordenaciones de 0..n-1.
+func permutaciones(n int) [][]int {
+	if n == 0 {
+		return [][]int{{}}
+	}
+	resultado := make([][]int, 0)
+	for _, p := range permutaciones(n - 1) {
+		for pos := 0; pos <= len(p); pos++ {
+			nueva := make([]int, 0, n)
+			nueva = append(nueva, p[:pos]...)
+			nueva = append(nueva, n-1)
+			nueva = append(nueva, p[pos:]...)
+			resultado = append(resultado, nueva)
+		}
+	}
+	return resultado
+}
+
+// combinaciones devuelve todos los subconjuntos de tamaño k de 0..n-1, en orden.
+func combinaciones(n, k int) [][]int {
+	resultado := make([][]int, 0)
+	actual := make([]int, 0, k)
+	var generar func(desde int)
+	generar = func(desde int) {
+		if len(actual) == k {
+			resultado = append(resultado, append([]int(nil), actual...))
+			return
+		}
+		for i := desde; i < n; i++ {
+			actual = append(actual, i)
+			generar(i + 1)
+			actual = actual[:len(actual)-1]
+		}
+	}
+	generar(0)
+	return resultado
+}
+
+// torneo lleva la cuenta de un torneo en curso.
+type torneo struct {
+	cfg           ConfigTorneo
+	clasificacion map[string]*ClasificacionTorneo
+	enfrentados   map[string]map[string]bool
+	partidas      []PartidaTorneo
+}
+
+// jugarEncuentros juega una ronda de encuentros (cada uno un grupo de estrategias)
+// y actualiza la clasificación en el orden de los encuentros, sea cual sea el paralelismo.
+func (t *torneo) jugarEncuentros(ronda int, encuentros [][]string) {
+	trabajos := make([]trabajoPartida, 0)
+	for _, mesa := range encuentros {
+		for reparto := 0; reparto < t.cfg.Repartos; reparto++ {
+			for _, orden := range permutaciones(len(mesa)) {
+				// El identificador es el número de la partida en el CSV.
+				id := strconv.Itoa(len(t.partidas) + len(trabajos) + 1)
+				// Cada ronda suiza juega repartos nuevos, para que sus partidas no
+				// repitan las de rondas anteriores.
+				semilla := t.cfg.Semilla + int64((ronda-1)*t.cfg.Repartos+reparto)
+				trabajos = append(trabajos, trabajoPartida{id: id, estrategias: mesa, orden: orden, semilla: semilla})
+			}
+		}
+	}
+	resultados := jugarPartidasSimuladas(trabajos, t.cfg.Paralelo, t.cfg.Registro)
+
+	victoriasEncuentro := make(map[string]int)
+	partidasEncuentro := make(map[string]int)
+	for i, r := range resultados {
+		trabajo := trabajos[i]
+		partida := PartidaTorneo{
+			Ronda:       ronda,
+			Semilla:     trabajo.semilla,
+			Ganador:     trabajo.estrategias[r.ganador],
+			Turnos:      r.turnos,
+			MazoAgotado: r.mazoAgotado,
+		}
+		for _, participante := range trabajo.orden {
+			partida.Asientos = append(partida.Asientos, trabajo.estrategias[participante])
+			partida.Puntos = append(partida.Puntos, r.puntos[participante])
+		}
+		t.partidas = append(t.partidas, partida)
+		for _, nombre := range trabajo.estrategias {
+			t.clasificacion[nombre].Partidas++
+			partidasEncuentro[nombre]++
+		}
+		t.clasificacion[partida.Ganador].Victorias++
+		victoriasEncuentro[partida.Ganador]++
+		t.actualizarElo(partida.Ganador, trabajo.estrategias)
+	}
+	for _, mesa := range encuentros {
+		for _, nombre := range mesa {
+			t.clasificacion[nombre].Puntos += float64(victoriasEncuentro[nombre]) / float64(partidasEncuentro[nombre])
+			for _, rival := range mesa {
+				if rival != nombre {
+					t.enfrentados[nombre][rival] = true
+				}
+			}
+		}
+	}
+}
+
+// actualizarElo trata una partida de varios jugadores como victorias del ganador sobre
+// cada rival, repartiendo el factor K entre ellos.
+func (t *torneo) actualizarElo(ganador string, mesa []string) {
+	k := eloK / float64(len(mesa)-1)
+	g := t.clasificacion[ganador]
+	for _, nombre := range mesa {
+		if nombre == ganador {
+			continue
+		}
+		p := t.clasificacion[nombre]
+		esperado := 1 / (1 + math.Pow(10, (p.Elo-g.Elo)/400))
+		g.Elo += k * (1 - esperado)
+		p.Elo -= k * (1 - esperado)
+	}
+}
+
+// ordenados devuelve las estrategias de mejor a peor: por puntos de encuentro, Elo y nombre.
+func (t *torneo) ordenados() []ClasificacionTorneo {
+	filas := make([]ClasificacionTorneo, 0, len(t.clasificacion))
+	for _, c := range t.clasificacion {
+		filas = append(filas, *c)
+	}
+	sort.Slice(filas, func(i, j int) bool {
+		if filas[i].Puntos != filas[j].Puntos {
+			return filas[i].Puntos > filas[j].Puntos
+		}
+		if filas[i].Elo != filas[j].Elo {
+			return filas[i].Elo > filas[j].Elo
+		}
+		return filas[i].Estrategia < filas[j].Estrategia
+	})
+	return filas
+}
+
+// emparejarSuizo forma las mesas de una ronda suiza: la mejor estrategia libre se sienta
+// con las siguientes de la clasificación, evitando repetir rivales cuando se puede.
+// Si sobra una sola estrategia, descansa y se lleva un punto.
+func (t *torneo) emparejarSuizo() [][]string {
+	libres := make([]string, 0)
+	for _, c := range t.ordenados() {
+		libres = append(libres, c.Estrategia)
+	}
+	mesas := make([][]string, 0)
+	for len(libres) >= 2 {
+		mesa := []string{libres[0]}
+		libres = libres[1:]
+		for len(mesa) < t.cfg.PorMesa && len(libres) > 0 {
+			elegido := 0
+			for i, candidato := range libres {
+				repetido := false
+				for _, sentado := range mesa {
+					if t.enfrentados[sentado][candidato] {
+						repetido = true
+					}
+				}
+				if !repetido {
+					elegido = i
+					break
+				}
+			}
+			mesa = append(mesa, libres[elegido])
+			libres = append(libres[:elegido], libres[elegido+1:]...)
+		}
+		mesas = append(mesas, mesa)
+	}
+	for _, descansa := range libres {
+		t.clasificacion[descansa].Puntos++
+	}
+	return mesas
+}
+
+// jugarTorneo juega el torneo completo.
+func jugarTorneo(cfg ConfigTorneo) (ResultadoTorneo, error) {
+	if len(cfg.Estrategias) < 2 {
+		return ResultadoTorneo{}, fmt.Errorf(idioma.T("se necesitan al menos 2 estrategias, hay %d"), len(cfg.Estrategias))
+	}
+	if err := validarEstrategias(cfg.Estrategias); err != nil {
+		return ResultadoTorneo{}, err
+	}
+	if cfg.PorMesa < 2 || cfg.PorMesa > 4 || cfg.PorMesa > len(cfg.Estrategias) {
+		return ResultadoTorneo{}, fmt.Errorf(idioma.T("jugadores por mesa inválidos: %d (entre 2 y 4, y no más que estrategias)"), cfg.PorMesa)
+	}
+	if cfg.Repartos < 1 {
+		return ResultadoTorneo{}, errors.New(idioma.T("el número de repartos debe ser positivo"))
+	}
+	t := &torneo{
+		cfg:           cfg,
+		clasificacion: make(map[string]*ClasificacionTorneo),
+		enfrentados:   make(map[string]map[string]bool),
+	}
+	for _, nombre := range cfg.Estrategias {
+		if _, repetida := t.clasificacion[nombre]; repetida {
+			return ResultadoTorneo{}, fmt.Errorf(idioma.T("la estrategia '%s' aparece más de una vez"), nombre)
+		}
+		t.clasificacion[nombre] = &ClasificacionTorneo{Estrategia: nombre, Elo: eloInicial}
+		t.enfrentados[nombre] = make(map[string]bool)
+	}
+
+	switch cfg.Formato {
+	case "liga":
+		encuentros := make([][]string, 0)
+		for _, combinacion := range combinaciones(len(cfg.Estrategias), cfg.PorMesa) {
+			mesa := make([]string, len(combinacion))
+			for i, indice := range combinacion {
+				mesa[i] = cfg.Estrategias[indice]
+			}
+			encuentros = append(encuentros, mesa)
+		}
+		t.jugarEncuentros(1, encuentros)
+	case "suizo":
+		if cfg.Rondas < 1 {
+			return ResultadoTorneo{}, errors.New(idioma.T("el número de rondas debe ser positivo"))
+		}
+		for ronda := 1; ronda <= cfg.Rondas; ronda++ {
+			t.jugarEncuentros(ronda, t.emparejarSuizo())
+		}
+	default:
+		return ResultadoTorneo{}, fmt.Errorf(idioma.T("formato de torneo desconocido '%s' (usa liga o suizo)"), cfg.Formato)
+	}
+	return ResultadoTorneo{Formato: cfg.Formato, Clasificacion: t.ordenados(), Partidas: t.partidas}, nil
+}
+
+// Imprimir escribe la tabla de clasificación.
+func (r ResultadoTorneo) Imprimir(w io.Writer) {
+	fmt.Fprintf(w, idioma.T("Torneo (%s): %d partidas\n\n"), r.Formato, len(r.Partidas))
+	fmt.Fprintf(w, "%3s %-16s %9s %10s %12s %8s %7s\n", "#", idioma.T("Estrategia"), idioma.T("Partidas"), idioma.T("Victorias"), idioma.T("% victorias"), idioma.T("Puntos"), "Elo")
+	for i, c := range r.Clasificacion {
+		porcentaje := 0.0
+		if c.Partidas > 0 {
+			porcentaje = 100 * float64(c.Victorias) / float64(c.Partidas)
+		}
+		fmt.Fprintf(w, "%3d %-16s %9d %10d %11.1f%% %8.2f %7.0f\n", i+1, c.Estrategia, c.Partidas, c.Victorias, porcentaje, c.Puntos, c.Elo)
+	}
+}
+
+// EscribirCSV exporta una fila por jugador y partida, lista para herramientas de análisis.
+func (r ResultadoTorneo) EscribirCSV(w io.Writer) error {
+	escritor := csv.NewWriter(w)
+	escritor.Write([]string{"partida", "ronda", "semilla", "asiento", "estrategia", "gano", "puntos_en_mano", "turnos", "mazo_agotado"})
+	for i, p := range r.Partidas {
+		for asiento, estrategia := range p.Asientos {
+			escritor.Write([]string{
+				strconv.Itoa(i + 1),
+				strconv.Itoa(p.Ronda),
+				strconv.FormatInt(p.Semilla, 10),
+				strconv.Itoa(asiento + 1),
+				estrategia,
+				strconv.FormatBool(estrategia == p.Ganador),
+				strconv.Itoa(p.Puntos[asiento]),
+				strconv.Itoa(p.Turnos),
+				strconv.FormatBool(p.MazoAgotado),
+			})
+		}
+	}
+	escritor.Flush()
+	return escritor.Error()
+}
+
+// ejecutarTorneo implementa el subcomando "torneo".
+func ejecutarTorneo(args []string, salida io.Writer) error {
+	flags := flag.NewFlagSet("torneo", flag.ContinueOnError)
+	estrategias := flags.String("estrategias", strings.Join(bots.Nombres(), ","), idioma.T("estrategias participantes separadas por comas"))
+	formato := flags.String("formato", "liga", idioma.T("formato: liga (todos contra todos) o suizo"))
+	porMesa := flags.Int("por-mesa", 2, idioma.T("jugadores por partida (2-4)"))
+	repartos := flags.Int("repartos", 50, idioma.T("repartos por encuentro; cada uno se juega en todas las permutaciones de asientos"))
+	rondas := flags.Int("rondas", 3, idioma.T("rondas del formato suizo"))
+	paralelo := flags.Int("paralelo", runtime.NumCPU(), idioma.T("partidas que se juegan a la vez"))
+	semilla := flags.Int64("semilla", 0, idioma.T("semilla base de los repartos (0: aleatoria)"))
+	archivoCSV := flags.String("csv", "", idioma.T("archivo donde guardar el resultado de cada partida en CSV"))
+	flags.Var(botsExternos{}, "externo", idioma.T("registra un programa externo como estrategia: nombre=comando (se puede repetir)"))
+	archivoRegistro := flagRegistro(flags)
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *semilla == 0 {
+		*semilla = time.Now().UnixNano()
+	}
+	nombres := strings.Split(*estrategias, ",")
+	for i := range nombres {
+		nombres[i] = strings.TrimSpace(nombres[i])
+	}
+	registro, archivo, err := abrirRegistro(*archivoRegistro)
+	if err != nil {
+		return err
+	}
+	resultado, err := jugarTorneo(ConfigTorneo{
+		Estrategias: nombres,
+		Formato:     *formato,
+		PorMesa:     *porMesa,
+		Repartos:    *repartos,
+		Rondas:      *rondas,
+		Paralelo:    *paralelo,
+		Semilla:     *semilla,
+		Registro:    registro,
+	})
+	if err := cerrarRegistro(registro, archivo); err != nil {
+		return err
+	}
+	if err != nil {
+		return err
+	}
+	resultado.Imprimir(salida)
+	fmt.Fprintf(salida, idioma.T("\nSemilla: %d\n"), *semilla)
+	if *archivoCSV != "" {
+		f, err := os.Create(*archivoCSV)
+		if err != nil {
+			return err
+		}
+		// Un error al cerrar puede dejar el archivo a medias: también se informa.
+		err = resultado.EscribirCSV(f)
+		if errCerrar := f.Close(); err == nil {
+			err = errCerrar
+		}
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(salida, idioma.T("Partidas guardadas en %s\n"), *archivoCSV)
+	}
+	return nil
+}
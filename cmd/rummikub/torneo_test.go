@@ -0,0 +1,100 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"testing"
+
+	"com.github/hapkiduki/rummikub/bots"
+	"com.github/hapkiduki/rummikub/mazo"
+	"com.github/hapkiduki/rummikub/motor"
+)
+
+// EstrategiaRobadora siempre roba: sirve como rival externo en los torneos de prueba.
+type EstrategiaRobadora struct{}
+
+func (EstrategiaRobadora) JugarTurno(jugador *motor.Jugador, mesa [][]mazo.Pieza) [][]mazo.Pieza {
+	return mesa
+}
+
+func TestJugarTorneo(t *testing.T) {
+	bots.Registrar("robadora", func() motor.Estrategia { return EstrategiaRobadora{} })
+	estrategias := []string{"novato", "intermedio", "robadora"}
+
+	casosDePrueba := []struct {
+		nombre   string
+		cfg      ConfigTorneo
+		partidas int // Partidas totales esperadas.
+	}{
+		{
+			// 3 encuentros × 2 repartos × 2 permutaciones de asientos.
+			nombre:   "Liga de dos en dos",
+			cfg:      ConfigTorneo{Estrategias: estrategias, Formato: "liga", PorMesa: 2, Repartos: 2, Semilla: 1, Paralelo: 2},
+			partidas: 12,
+		},
+		{
+			// Un solo encuentro × 1 reparto × 6 permutaciones.
+			nombre:   "Liga a tres",
+			cfg:      ConfigTorneo{Estrategias: estrategias, Formato: "liga", PorMesa: 3, Repartos: 1, Semilla: 1, Paralelo: 2},
+			partidas: 6,
+		},
+		{
+			// Cada ronda juega una mesa de dos y la tercera descansa.
+			nombre:   "Suizo",
+			cfg:      ConfigTorneo{Estrategias: estrategias, Formato: "suizo", PorMesa: 2, Repartos: 2, Rondas: 3, Semilla: 1, Paralelo: 2},
+			partidas: 12,
+		},
+	}
+
+	for _, tc := range casosDePrueba {
+		t.Run(tc.nombre, func(t *testing.T) {
+			resultado, err := jugarTorneo(tc.cfg)
+			if err != nil {
+				t.Fatalf("Error inesperado: %v", err)
+			}
+			if len(resultado.Partidas) != tc.partidas {
+				t.Errorf("Se esperaban %d partidas, se jugaron %d", tc.partidas, len(resultado.Partidas))
+			}
+			if len(resultado.Clasificacion) != len(estrategias) {
+				t.Fatalf("Se esperaban %d estrategias en la clasificación, hay %d", len(estrategias), len(resultado.Clasificacion))
+			}
+			// Elo es de suma cero: la media sigue siendo la inicial.
+			suma := 0.0
+			for _, c := range resultado.Clasificacion {
+				suma += c.Elo
+			}
+			if media := suma / float64(len(estrategias)); media < eloInicial-0.001 || media > eloInicial+0.001 {
+				t.Errorf("Se esperaba un Elo medio de %.0f, se obtuvo %.3f", eloInicial, media)
+			}
+
+			// Las rondas no repiten repartos: cada semilla es de una sola ronda.
+			rondaDeSemilla := make(map[int64]int)
+			for _, p := range resultado.Partidas {
+				if ronda, ok := rondaDeSemilla[p.Semilla]; ok && ronda != p.Ronda {
+					t.Errorf("Se esperaba que la semilla %d fuera solo de la ronda %d, pero se repite en la ronda %d", p.Semilla, ronda, p.Ronda)
+				}
+				rondaDeSemilla[p.Semilla] = p.Ronda
+			}
+
+			var buf bytes.Buffer
+			if err := resultado.EscribirCSV(&buf); err != nil {
+				t.Fatalf("Error al escribir el CSV: %v", err)
+			}
+			filas, err := csv.NewReader(&buf).ReadAll()
+			if err != nil {
+				t.Fatalf("CSV inválido: %v", err)
+			}
+			if esperadas := 1 + tc.partidas*tc.cfg.PorMesa; len(filas) != esperadas {
+				t.Errorf("Se esperaban %d filas en el CSV, hay %d", esperadas, len(filas))
+			}
+		})
+	}
+}
+
+func TestPermutaciones(t *testing.T) {
+	for n, esperadas := range map[int]int{1: 1, 2: 2, 3: 6, 4: 24} {
+		if obtenidas := len(permutaciones(n)); obtenidas != esperadas {
+			t.Errorf("Para %d jugadores se esperaban %d permutaciones, se obtuvieron %d", n, esperadas, obtenidas)
+		}
+	}
+}
@@ -0,0 +1,510 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"sort"
+	"strconv"
+	"strings"
+
+	"com.github/hapkiduki/rummikub/bots"
+	"com.github/hapkiduki/rummikub/idioma"
+	"com.github/hapkiduki/rummikub/mazo"
+	"com.github/hapkiduki/rummikub/motor"
+	"com.github/hapkiduki/rummikub/reglas"
+)
+
+// --- INTERFAZ DE TERMINAL A PANTALLA COMPLETA ---
+//
+// Durante el turno del jugador la terminal pasa a modo "raw" (con stty) y a la
+// pantalla alternativa, de forma que la narración de los bots sigue quedando en
+// la pantalla normal. Si la terminal no lo permite se usa EstrategiaHumano.
+
+// terminalSoportaTUI indica si la entrada y la salida son una terminal capaz de
+// mostrar la interfaz a pantalla completa.
+func terminalSoportaTUI() bool {
+	term := os.Getenv("TERM")
+	if term == "" || term == "dumb" {
+		return false
+	}
+	for _, f := range []*os.File{os.Stdin, os.Stdout} {
+		info, err := f.Stat()
+		if err != nil || info.Mode()&os.ModeCharDevice == 0 {
+			return false
+		}
+	}
+	_, err := exec.LookPath("stty")
+	return err == nil
+}
+
+// stty ejecuta stty sobre la terminal de la entrada estándar.
+func stty(args ...string) (string, error) {
+	cmd := exec.Command("stty", args...)
+	cmd.Stdin = os.Stdin
+	salida, err := cmd.Output()
+	return strings.TrimSpace(string(salida)), err
+}
+
+type tecla int
+
+const (
+	teclaNinguna tecla = iota
+	teclaArriba
+	teclaAbajo
+	teclaIzquierda
+	teclaDerecha
+	teclaTab
+	teclaEnter
+	teclaEspacio
+	teclaRobar
+	teclaOrden
+	teclaPista
+	teclaDeshacer
+	teclaComando
+	teclaSalir
+)
+
+// decodificarTecla traduce los bytes leídos en modo raw a una tecla.
+func decodificarTecla(b []byte) tecla {
+	if len(b) >= 3 && b[0] == 0x1b && b[1] == '[' {
+		switch b[2] {
+		case 'A':
+			return teclaArriba
+		case 'B':
+			return teclaAbajo
+		case 'C':
+			return teclaDerecha
+		case 'D':
+			return teclaIzquierda
+		}
+		return teclaNinguna
+	}
+	if len(b) != 1 {
+		return teclaNinguna
+	}
+	switch b[0] {
+	case '\t':
+		return teclaTab
+	case '\r', '\n':
+		return teclaEnter
+	case ' ':
+		return teclaEspacio
+	case 'r', 'R':
+		return teclaRobar
+	case 'o', 'O':
+		return teclaOrden
+	case 'p', 'P':
+		return teclaPista
+	case 'u', 'U':
+		return teclaDeshacer
+	case ':':
+		return teclaComando
+	case 'q', 'Q', 3: // 3 es Ctrl-C, que en modo raw no genera señal.
+		return teclaSalir
+	case 'k':
+		return teclaArriba
+	case 'j':
+		return teclaAbajo
+	case 'h':
+		return teclaIzquierda
+	case 'l':
+		return teclaDerecha
+	}
+	return teclaNinguna
+}
+
+// vistaTUI guarda el cursor y la selección del jugador durante su turno.
+type vistaTUI struct {
+	mano       []mazo.Pieza
+	filas      [][]int // índices de la mano agrupados según modo (los comodines al final)
+	modo       reglas.ModoOrden
+	numJugadas int
+	enMesa     bool // true si el cursor está sobre la mesa en vez de sobre la mano
+	fila       int
+	columna    int
+	jugada     int
+	seleccion  map[int]bool
+	mensaje    string
+	// Línea de comandos, que se abre con ':'.
+	escribiendo bool
+	comando     string
+	historial   int // Posición al recorrer el historial con las flechas.
+}
+
+// nuevaVistaTUI ordena la mano según el modo del jugador y prepara una fila por grupo.
+func nuevaVistaTUI(jugador *motor.Jugador, numJugadas int) *vistaTUI {
+	filas := reglas.OrdenarMano(jugador.Mano, jugador.OrdenMano)
+	return &vistaTUI{mano: jugador.Mano, filas: filas, modo: jugador.OrdenMano, numJugadas: numJugadas, seleccion: make(map[int]bool)}
+}
+
+// indiceActual devuelve el índice en la mano de la ficha bajo el cursor, o -1.
+func (v *vistaTUI) indiceActual() int {
+	if v.fila >= len(v.filas) || v.columna >= len(v.filas[v.fila]) {
+		return -1
+	}
+	return v.filas[v.fila][v.columna]
+}
+
+func (v *vistaTUI) indicesSeleccionados() []int {
+	indices := make([]int, 0, len(v.seleccion))
+	for i := range v.seleccion {
+		indices = append(indices, i)
+	}
+	sort.Ints(indices)
+	return indices
+}
+
+// procesar aplica una tecla a la vista. Devuelve el movimiento elegido cuando
+// el jugador confirma una acción, y salir cuando quiere abandonar la partida.
+func (v *vistaTUI) procesar(t tecla) (mov *motor.Movimiento, salir bool) {
+	v.mensaje = ""
+	switch t {
+	case teclaSalir:
+		return nil, true
+	case teclaComando:
+		v.escribiendo = true
+		v.comando = ""
+		v.historial = -1
+	case teclaTab:
+		if v.numJugadas == 0 {
+			v.mensaje = idioma.T("La mesa está vacía.")
+			return nil, false
+		}
+		v.enMesa = !v.enMesa
+	case teclaArriba, teclaIzquierda, teclaAbajo, teclaDerecha:
+		v.mover(t)
+	case teclaEspacio:
+		if i := v.indiceActual(); i >= 0 && !v.enMesa {
+			if v.seleccion[i] {
+				delete(v.seleccion, i)
+			} else {
+				v.seleccion[i] = true
+			}
+		}
+	case teclaRobar:
+		return &motor.Movimiento{Accion: motor.MovimientoRobar}, false
+	case teclaEnter:
+		if v.enMesa {
+			indices := v.indicesSeleccionados()
+			if len(indices) == 0 && v.indiceActual() >= 0 {
+				indices = []int{v.indiceActual()}
+			}
+			if len(indices) != 1 {
+				v.mensaje = idioma.T("Para añadir a una jugada selecciona exactamente una ficha.")
+				return nil, false
+			}
+			return &motor.Movimiento{Accion: motor.MovimientoAnadir, Indices: indices, Jugada: v.jugada}, false
+		}
+		if len(v.seleccion) == 0 {
+			v.mensaje = idioma.T("Selecciona fichas con Espacio antes de bajar una jugada.")
+			return nil, false
+		}
+		return &motor.Movimiento{Accion: motor.MovimientoJugar, Indices: v.indicesSeleccionados()}, false
+	}
+	return nil, false
+}
+
+// editar aplica a la línea de comandos los bytes leídos del teclado: texto, borrar,
+// Tab para completar, flechas para recorrer el historial y Esc para cerrarla.
+// Devuelve la línea cuando el jugador pulsa Enter.
+func (v *vistaTUI) editar(b []byte, historial []string) (string, bool) {
+	v.mensaje = ""
+	if len(b) >= 3 && b[0] == 0x1b && b[1] == '[' {
+		if len(historial) == 0 {
+			return "", false
+		}
+		if v.historial < 0 {
+			v.historial = len(historial)
+		}
+		switch b[2] {
+		case 'A':
+			v.historial = max(v.historial-1, 0)
+		case 'B':
+			v.historial = min(v.historial+1, len(historial))
+		}
+		v.comando = ""
+		if v.historial < len(historial) {
+			v.comando = historial[v.historial]
+		}
+		return "", false
+	}
+	switch {
+	case len(b) == 1 && (b[0] == 0x1b || b[0] == 3): // Esc o Ctrl-C.
+		v.escribiendo = false
+	case len(b) == 1 && (b[0] == '\r' || b[0] == '\n'):
+		v.escribiendo = false
+		return v.comando, true
+	case len(b) == 1 && b[0] == '\t':
+		var sugerencias []string
+		v.comando, sugerencias = completarComando(v.comando)
+		v.mensaje = strings.Join(sugerencias, "  ")
+	case len(b) == 1 && (b[0] == 127 || b[0] == 8): // Retroceso.
+		if letras := []rune(v.comando); len(letras) > 0 {
+			v.comando = string(letras[:len(letras)-1])
+		}
+	case b[0] >= ' ':
+		v.comando += string(b)
+	}
+	return "", false
+}
+
+func (v *vistaTUI) mover(t tecla) {
+	if v.enMesa {
+		switch t {
+		case teclaArriba, teclaIzquierda:
+			if v.jugada > 0 {
+				v.jugada--
+			}
+		case teclaAbajo, teclaDerecha:
+			if v.jugada < v.numJugadas-1 {
+				v.jugada++
+			}
+		}
+		return
+	}
+	if len(v.filas) == 0 {
+		return
+	}
+	switch t {
+	case teclaArriba:
+		if v.fila > 0 {
+			v.fila--
+		}
+	case teclaAbajo:
+		if v.fila < len(v.filas)-1 {
+			v.fila++
+		}
+	case teclaIzquierda:
+		if v.columna > 0 {
+			v.columna--
+		}
+	case teclaDerecha:
+		v.columna++
+	}
+	if v.columna >= len(v.filas[v.fila]) {
+		v.columna = len(v.filas[v.fila]) - 1
+	}
+}
+
+// Colores ANSI de primer plano para Rojo, Azul, Amarillo y Negro.
+var coloresANSI = []int{31, 34, 33, 30}
+
+// nombresColor son los nombres de cada fila de la mano.
+var nombresColor = []string{"Rojo", "Azul", "Amarillo", "Negro"}
+
+// fichaTUI dibuja una ficha como una caja de color.
+func fichaTUI(p mazo.Pieza, cursor, seleccionada bool) string {
+	fondo := 107 // Blanco brillante, como las fichas de verdad.
+	if seleccionada {
+		fondo = 106
+	}
+	if cursor {
+		fondo = 103
+	}
+	color, texto := 35, "J" // Comodín en magenta.
+	if p.Numero != 0 {
+		color, texto = coloresANSI[p.Color], fmt.Sprint(p.Numero)
+	}
+	return fmt.Sprintf("\x1b[1;%d;%dm%3s \x1b[0m", color, fondo, texto)
+}
+
+// nombreFila devuelve la etiqueta de una fila de la mano según el modo de orden.
+func (v *vistaTUI) nombreFila(fila []int) string {
+	primera := v.mano[fila[0]]
+	if primera.Numero == 0 {
+		return idioma.T("Comodines")
+	}
+	if v.modo == reglas.OrdenPorNumero {
+		return idioma.Tf("Número %d", primera.Numero)
+	}
+	if v.modo == reglas.OrdenSugerido && len(fila) >= 3 {
+		fichas := make([]mazo.Pieza, len(fila))
+		for i, indice := range fila {
+			fichas[i] = v.mano[indice]
+		}
+		if reglas.EsJugadaValida(fichas) {
+			return idioma.T("Sugerida")
+		}
+	}
+	return idioma.T(nombresColor[primera.Color])
+}
+
+// pintar compone la pantalla completa del turno.
+func (v *vistaTUI) pintar(partida *motor.Partida, marcador *motor.Marcador, jugador *motor.Jugador, mesa [][]mazo.Pieza) string {
+	var b strings.Builder
+	linea := func(format string, args ...any) {
+		fmt.Fprintf(&b, idioma.T(format), args...)
+		b.WriteString("\x1b[K\r\n")
+	}
+	b.WriteString("\x1b[H")
+	linea("\x1b[1m Rummikub en Go\x1b[0m — turno de %s — mazo: %d fichas", jugador.Nombre, partida.Pozo.Restantes())
+	linea("")
+	for _, l := range lineasEstado(partida.Estado(nil), marcador) {
+		linea(" %s", l)
+	}
+	linea("")
+	linea("\x1b[1m Mesa\x1b[0m")
+	if len(mesa) == 0 {
+		linea("  La mesa está vacía.")
+	}
+	for i, jugada := range mesa {
+		marca := "  "
+		if v.enMesa && i == v.jugada {
+			marca = "\x1b[1;33m▶\x1b[0m "
+		}
+		fichas := make([]string, len(jugada))
+		for k, p := range jugada {
+			fichas[k] = fichaTUI(p, false, false)
+		}
+		linea("%s%2d  %s", marca, i, strings.Join(fichas, " "))
+	}
+	linea("")
+	abierto := idioma.T("sin abrir: tu primera jugada debe sumar 30 puntos")
+	if jugador.HaHechoPrimeraJugada {
+		abierto = idioma.T("ya has abierto")
+	}
+	linea("\x1b[1m Tu mano\x1b[0m (%d fichas, %s, orden %s)", len(v.mano), abierto, v.modo)
+	for f, fila := range v.filas {
+		nombre := v.nombreFila(fila)
+		fichas := make([]string, len(fila))
+		for c, i := range fila {
+			fichas[c] = fichaTUI(v.mano[i], !v.enMesa && f == v.fila && c == v.columna, v.seleccion[i])
+		}
+		linea("  %-10s %s", nombre, strings.Join(fichas, " "))
+	}
+	linea("")
+	linea(" ←↑↓→ mover · Espacio seleccionar · Enter bajar/añadir · Tab mano/mesa · o orden · p pista · u deshacer · r terminar/robar · : comando · q salir")
+	linea(" \x1b[1;33m%s\x1b[0m", v.mensaje)
+	if v.escribiendo {
+		linea(" : %s\x1b[7m \x1b[0m", v.comando)
+	}
+	b.WriteString("\x1b[J")
+	return b.String()
+}
+
+// --- ESTRATEGIA: JUGADOR HUMANO CON INTERFAZ DE TERMINAL
+
+// EstrategiaTUI es el jugador humano con la interfaz a pantalla completa.
+type EstrategiaTUI struct {
+	partida   *motor.Partida
+	Pistas    bots.NivelPista
+	historial HistorialComandos
+	marcador  *motor.Marcador
+}
+
+// consola es la interfaz de texto que se usa cuando la terminal no admite la TUI.
+func (e *EstrategiaTUI) consola() EstrategiaHumano {
+	return EstrategiaHumano{Pistas: e.Pistas, partida: e.partida, historial: &e.historial, marcador: e.marcador}
+}
+
+// FichasRobadas le enseña al jugador lo que ha robado, fuera de la pantalla completa.
+func (e *EstrategiaTUI) FichasRobadas(jugador *motor.Jugador, fichas []mazo.Pieza) {
+	e.consola().FichasRobadas(jugador, fichas)
+}
+
+// JugarTurno atiende las teclas y la línea de comandos hasta que el jugador termina.
+func (e *EstrategiaTUI) JugarTurno(jugador *motor.Jugador, mesa [][]mazo.Pieza) [][]mazo.Pieza {
+	estadoTerminal, err := stty("-g")
+	if err != nil {
+		return e.consola().JugarTurno(jugador, mesa)
+	}
+	if _, err := stty("raw", "-echo"); err != nil {
+		return e.consola().JugarTurno(jugador, mesa)
+	}
+	fmt.Print("\x1b[?1049h\x1b[?25l") // Pantalla alternativa y cursor oculto.
+	restaurar := func() {
+		fmt.Print("\x1b[?25h\x1b[?1049l")
+		stty(estadoTerminal)
+	}
+
+	turno := nuevoTurnoHumano(jugador, mesa, e.partida, e.Pistas)
+	v := nuevaVistaTUI(jugador, len(turno.mesa))
+	fmt.Print("\x1b[2J")
+	buf := make([]byte, 8)
+	for {
+		fmt.Print(v.pintar(e.partida, e.marcador, jugador, turno.mesa))
+		n, err := os.Stdin.Read(buf)
+		if err != nil {
+			restaurar()
+			return e.consola().JugarTurno(jugador, turno.mesa)
+		}
+		var comando Comando
+		if v.escribiendo {
+			linea, enviar := v.editar(buf[:n], e.historial.Lineas())
+			if !enviar || strings.TrimSpace(linea) == "" {
+				continue
+			}
+			e.historial.Agregar(strings.TrimSpace(linea))
+			if comando, err = parsearComando(linea); err != nil {
+				v.mensaje = err.Error()
+				continue
+			}
+		} else {
+			t := decodificarTecla(buf[:n])
+			switch t {
+			case teclaOrden:
+				comando = Comando{Tipo: ComandoOrden}
+			case teclaPista:
+				comando = Comando{Tipo: ComandoPista}
+			case teclaDeshacer:
+				comando = Comando{Tipo: ComandoDeshacer}
+			case teclaRobar:
+				comando = Comando{Tipo: ComandoTerminar}
+			default:
+				mov, salir := v.procesar(t)
+				if salir {
+					restaurar()
+					fmt.Println(idioma.T("Has abandonado la partida."))
+					os.Exit(0)
+				}
+				if mov == nil {
+					continue
+				}
+				comando = Comando{Tipo: ComandoJugar, Fichas: textoIndices(mov.Indices)}
+				if mov.Accion == motor.MovimientoAnadir {
+					comando = Comando{Tipo: ComandoAnadir, Fichas: textoIndices(mov.Indices), Jugada: mov.Jugada}
+				}
+			}
+		}
+		switch comando.Tipo {
+		case ComandoMostrar:
+			continue
+		case ComandoAyuda:
+			v.mensaje = idioma.T("Comandos: ") + strings.Join(sugerenciasComando(""), ", ")
+			continue
+		case ComandoHistorial:
+			lineas := e.historial.Lineas()
+			v.mensaje = strings.Join(lineas[max(len(lineas)-6, 0):], " · ")
+			continue
+		}
+		mensaje, terminado, err := turno.ejecutar(comando)
+		if terminado {
+			restaurar()
+			if err != nil {
+				fmt.Printf(idioma.T("Movimiento inválido: %v. Tu turno ha terminado.\n"), err)
+			} else {
+				fmt.Println(mensaje)
+			}
+			return turno.mesa
+		}
+		if err != nil {
+			v.mensaje = idioma.Tf("No se puede: %v.", err)
+			continue
+		}
+		if comando.Tipo != ComandoPista && comando.Tipo != ComandoGuardar {
+			// La mano o la mesa han cambiado: se empieza con una vista nueva.
+			v = nuevaVistaTUI(jugador, len(turno.mesa))
+		}
+		v.mensaje = mensaje
+	}
+}
+
+// textoIndices escribe unos índices de la mano como los escribiría el jugador.
+func textoIndices(indices []int) string {
+	partes := make([]string, len(indices))
+	for i, indice := range indices {
+		partes[i] = strconv.Itoa(indice)
+	}
+	return strings.Join(partes, " ")
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	"com.github/hapkiduki/rummikub/mazo"
+	"com.github/hapkiduki/rummikub/motor"
+)
+
+func TestVistaTUIProcesar(t *testing.T) {
+	// La mano ya viene ordenada por color y número, como la deja JugarTurno.
+	mano := []mazo.Pieza{
+		{Color: mazo.Rojo, Numero: 7},
+		{Color: mazo.Rojo, Numero: 8},
+		{Color: mazo.Azul, Numero: 7},
+		{Color: mazo.Negro, Numero: 7},
+		{Color: -1, Numero: 0}, // Comodín
+	}
+	casosDePrueba := []struct {
+		nombre   string
+		teclas   []tecla
+		esperado *motor.Movimiento
+	}{
+		{
+			nombre:   "Bajar un trío bajando por las filas de colores",
+			teclas:   []tecla{teclaEspacio, teclaAbajo, teclaEspacio, teclaAbajo, teclaEspacio, teclaEnter},
+			esperado: &motor.Movimiento{Accion: motor.MovimientoJugar, Indices: []int{0, 2, 3}},
+		},
+		{
+			nombre:   "Deseleccionar una ficha",
+			teclas:   []tecla{teclaEspacio, teclaDerecha, teclaEspacio, teclaIzquierda, teclaEspacio, teclaEnter},
+			esperado: &motor.Movimiento{Accion: motor.MovimientoJugar, Indices: []int{1}},
+		},
+		{
+			nombre:   "Añadir la ficha bajo el cursor a la segunda jugada",
+			teclas:   []tecla{teclaAbajo, teclaAbajo, teclaAbajo, teclaTab, teclaDerecha, teclaEnter},
+			esperado: &motor.Movimiento{Accion: motor.MovimientoAnadir, Indices: []int{4}, Jugada: 1},
+		},
+		{
+			nombre:   "Enter sin selección no juega nada",
+			teclas:   []tecla{teclaEnter},
+			esperado: nil,
+		},
+		{
+			nombre:   "Robar",
+			teclas:   []tecla{teclaDerecha, teclaRobar},
+			esperado: &motor.Movimiento{Accion: motor.MovimientoRobar},
+		},
+	}
+
+	for _, tc := range casosDePrueba {
+		t.Run(tc.nombre, func(t *testing.T) {
+			v := nuevaVistaTUI(&motor.Jugador{Mano: append([]mazo.Pieza(nil), mano...)}, 2)
+			var resultado *motor.Movimiento
+			for _, tec := range tc.teclas {
+				resultado, _ = v.procesar(tec)
+			}
+			if !reflect.DeepEqual(resultado, tc.esperado) {
+				t.Errorf("Se esperaba %+v, pero se obtuvo %+v", tc.esperado, resultado)
+			}
+		})
+	}
+}
+
+func TestDecodificarTecla(t *testing.T) {
+	casosDePrueba := map[string]tecla{
+		"\x1b[A": teclaArriba,
+		"\x1b[D": teclaIzquierda,
+		"\r":     teclaEnter,
+		" ":      teclaEspacio,
+		"\t":     teclaTab,
+		"r":      teclaRobar,
+		"\x03":   teclaSalir,
+		"x":      teclaNinguna,
+	}
+	for entrada, esperada := range casosDePrueba {
+		if resultado := decodificarTecla([]byte(entrada)); resultado != esperada {
+			t.Errorf("Para %q se esperaba %v, pero se obtuvo %v", entrada, esperada, resultado)
+		}
+	}
+}
+
+func TestVistaTUIEditar(t *testing.T) {
+	historial := []string{"play R7 R8 R9", "hint"}
+	casosDePrueba := []struct {
+		nombre   string
+		entradas []string
+		comando  string // Lo que queda escrito, o la línea enviada si enviado es true.
+		enviado  bool
+	}{
+		{nombre: "Escribir y enviar", entradas: []string{"d", "o", "n", "e", "\r"}, comando: "done", enviado: true},
+		{nombre: "Completar con Tab", entradas: []string{"s", "p", "\t"}, comando: "split "},
+		{nombre: "Borrar", entradas: []string{"a", "ñ", "\x7f", "d"}, comando: "ad"},
+		{nombre: "Recorrer el historial", entradas: []string{"\x1b[A", "\x1b[A", "\x1b[B"}, comando: "hint"},
+		{nombre: "Esc cierra la línea", entradas: []string{"u", "\x1b"}, comando: "u"},
+	}
+	for _, tc := range casosDePrueba {
+		t.Run(tc.nombre, func(t *testing.T) {
+			v := nuevaVistaTUI(&motor.Jugador{}, 0)
+			v.procesar(teclaComando)
+			var linea string
+			var enviado bool
+			for _, entrada := range tc.entradas {
+				linea, enviado = v.editar([]byte(entrada), historial)
+			}
+			if !enviado {
+				linea = v.comando
+			}
+			if linea != tc.comando || enviado != tc.enviado {
+				t.Errorf("Se esperaba %q (enviado: %v), pero se obtuvo %q (%v)", tc.comando, tc.enviado, linea, enviado)
+			}
+		})
+	}
+}
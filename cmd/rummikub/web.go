@@ -0,0 +1,314 @@
+package main
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"strconv"
+	"sync"
+
+	"com.github/hapkiduki/rummikub/bots"
+	"com.github/hapkiduki/rummikub/idioma"
+	"com.github/hapkiduki/rummikub/mazo"
+	"com.github/hapkiduki/rummikub/motor"
+	"com.github/hapkiduki/rummikub/reglas"
+)
+
+// --- MODO WEB (NAVEGADOR) ---
+//
+// El servidor web sienta a un jugador en el navegador contra los bots y expone
+// la partida con endpoints JSON:
+//
+//	GET  /api/textos  textos de la interfaz en el idioma activo (ver TextosWeb)
+//	GET  /api/estado  foto de la partida vista por el jugador web (ver RespuestaWeb)
+//	POST /api/jugada  cuerpo: un Movimiento, p. ej. {"accion":"jugar","indices":[0,4,8]}
+//	POST /api/nueva   empieza otra partida cuando la actual ha terminado
+//
+// El navegador consulta /api/estado periódicamente para ver avanzar a los bots.
+
+//go:embed web
+var archivosWeb embed.FS
+
+// RespuestaWeb es lo que devuelven los endpoints de la API web.
+type RespuestaWeb struct {
+	Estado    motor.EstadoPartida `json:"estado"`
+	EsTuTurno bool                `json:"es_tu_turno"`
+	Error     string              `json:"error,omitempty"`
+}
+
+// TextosWeb son los textos de la interfaz del navegador traducidos al idioma activo.
+// index.html no lleva texto propio: los pide a /api/textos y los busca por clave.
+type TextosWeb struct {
+	Idioma idioma.Idioma     `json:"idioma"`
+	Textos map[string]string `json:"textos"`
+}
+
+// textosWeb traduce los textos de index.html. Los %s y %d los rellena el navegador.
+func textosWeb() TextosWeb {
+	return TextosWeb{Idioma: idioma.Actual(), Textos: map[string]string{
+		"titulo":        idioma.T("Rummikub en Go"),
+		"mesa":          idioma.T("Mesa de juego"),
+		"nueva":         idioma.T("Nueva jugada"),
+		"instrucciones": idioma.T("Arrastra fichas aquí (o haz clic en ellas) y pulsa «Bajar jugada». Para añadir una ficha a la mesa, arrástrala sobre la jugada."),
+		"mano":          idioma.T("Tu mano"),
+		"bajar":         idioma.T("Bajar jugada"),
+		"robar":         idioma.T("Robar ficha"),
+		"otra":          idioma.T("Nueva partida"),
+		"jugador":       idioma.T("Jugador"),
+		"fichas":        idioma.T("Fichas"),
+		"abierto":       idioma.T("Ha abierto"),
+		"si":            idioma.T("sí"),
+		"no":            idioma.T("no"),
+		"mazo":          idioma.T("Fichas en el mazo: %d"),
+		"mesa_vacia":    idioma.T("La mesa está vacía."),
+		"jugada":        idioma.T("Jugada %d"),
+		"fin":           idioma.T("Fin de la partida. Ganador: %s"),
+		"tu_turno":      idioma.T("Es tu turno."),
+		"turno_de":      idioma.T("Turno de %s..."),
+		"error":         idioma.T("Error: %s"),
+	}}
+}
+
+// peticionMovimiento lleva un movimiento del navegador al bucle de la partida.
+// La respuesta es la foto al acabar el turno del navegador, antes de que jueguen los bots.
+type peticionMovimiento struct {
+	movimiento motor.Movimiento
+	respuesta  chan RespuestaWeb
+}
+
+// OpcionesWeb configura las partidas de un ServidorWeb.
+type OpcionesWeb struct {
+	Reglas     motor.ReglasCasa
+	Registro   *motor.Registro // Si no es nil, recibe los eventos de todas las partidas.
+	Silencioso bool            // Sin narración en la consola ni pausas de los bots (para pruebas).
+}
+
+// ServidorWeb sirve la interfaz del navegador y dirige la partida del jugador web.
+type ServidorWeb struct {
+	numJugadores int
+	opciones     OpcionesWeb
+	movimientos  chan peticionMovimiento
+	partidas     int // Partidas empezadas, para el registro; solo lo toca empezarPartida.
+	cerrado      chan struct{}
+	cerrar       sync.Once
+	juegos       sync.WaitGroup // Goroutines de partida en marcha.
+
+	mu        sync.Mutex // protege los campos de abajo
+	estado    motor.EstadoPartida
+	esTuTurno bool
+	enCurso   bool
+}
+
+// NuevoServidorWeb prepara un servidor web para partidas de numJugadores
+// (el jugador del navegador más bots) y empieza la primera partida.
+// La partida sigue en segundo plano hasta que termina o se llama a Cerrar.
+func NuevoServidorWeb(numJugadores int, opciones OpcionesWeb) (*ServidorWeb, error) {
+	if numJugadores < 2 || numJugadores > 4 {
+		return nil, fmt.Errorf(idioma.T("número de jugadores inválido: %d (debe estar entre 2 y 4)"), numJugadores)
+	}
+	s := &ServidorWeb{
+		numJugadores: numJugadores,
+		opciones:     opciones,
+		movimientos:  make(chan peticionMovimiento),
+		cerrado:      make(chan struct{}),
+	}
+	s.empezarPartida()
+	return s, nil
+}
+
+// Cerrar detiene la partida en curso: el jugador web deja de esperar al navegador
+// y no se juegan más turnos. Vuelve cuando ha terminado el turno que se estaba jugando.
+// El Handler sigue sirviendo la última foto publicada.
+func (s *ServidorWeb) Cerrar() {
+	s.cerrar.Do(func() { close(s.cerrado) })
+	s.juegos.Wait()
+}
+
+// estaCerrado indica si ya se ha llamado a Cerrar.
+func (s *ServidorWeb) estaCerrado() bool {
+	select {
+	case <-s.cerrado:
+		return true
+	default:
+		return false
+	}
+}
+
+// Handler devuelve el http.Handler con la interfaz y la API.
+func (s *ServidorWeb) Handler() http.Handler {
+	mux := http.NewServeMux()
+	estaticos, _ := fs.Sub(archivosWeb, "web")
+	mux.Handle("GET /", http.FileServer(http.FS(estaticos)))
+	mux.HandleFunc("GET /api/textos", func(w http.ResponseWriter, r *http.Request) {
+		escribirJSON(w, http.StatusOK, textosWeb())
+	})
+	mux.HandleFunc("GET /api/estado", s.manejarEstado)
+	mux.HandleFunc("POST /api/jugada", s.manejarJugada)
+	mux.HandleFunc("POST /api/nueva", s.manejarNueva)
+	return mux
+}
+
+func (s *ServidorWeb) empezarPartida() {
+	jugadores := make([]*motor.Jugador, 0, s.numJugadores)
+	web := &motor.Jugador{Nombre: idioma.T("Tú (Navegador)"), Mano: make([]mazo.Pieza, 0, 14)}
+	jugadores = append(jugadores, web)
+	silencioso := s.opciones.Silencioso
+	bots := []motor.Estrategia{bots.EstrategiaIntermedio{Silencioso: silencioso, Grupos: s.opciones.Reglas.Grupos}, bots.EstrategiaNovato{Silencioso: silencioso}}
+	for i := 1; i < s.numJugadores; i++ {
+		jugadores = append(jugadores, &motor.Jugador{
+			Nombre:     fmt.Sprintf("Bot %d", i+1),
+			Mano:       make([]mazo.Pieza, 0, 14),
+			Estrategia: bots[i%len(bots)],
+		})
+	}
+	opciones := motor.OpcionesPartida{Reglas: s.opciones.Reglas, Silenciosa: silencioso}
+	s.partidas++
+	if s.opciones.Registro != nil {
+		opciones.Observadores = append(opciones.Observadores, s.opciones.Registro.Partida(strconv.Itoa(s.partidas)))
+	}
+	partida := motor.NuevaPartida(jugadores, opciones)
+	estrategia := &EstrategiaWeb{servidor: s, partida: partida}
+	web.Estrategia = estrategia
+	s.publicar(partida.Estado(web), false)
+	s.mu.Lock()
+	s.enCurso = true
+	s.mu.Unlock()
+	s.juegos.Add(1)
+	go func() {
+		defer s.juegos.Done()
+		for !partida.Terminada && !s.estaCerrado() {
+			partida.JugarTurno()
+			s.publicar(partida.Estado(web), false)
+			estrategia.contestar()
+		}
+		s.mu.Lock()
+		s.enCurso = false
+		s.mu.Unlock()
+	}()
+}
+
+// publicar guarda la foto que verá el navegador.
+func (s *ServidorWeb) publicar(estado motor.EstadoPartida, esTuTurno bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.estado = estado
+	s.esTuTurno = esTuTurno
+}
+
+func (s *ServidorWeb) respuesta() RespuestaWeb {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return RespuestaWeb{Estado: s.estado, EsTuTurno: s.esTuTurno}
+}
+
+func (s *ServidorWeb) manejarEstado(w http.ResponseWriter, r *http.Request) {
+	escribirJSON(w, http.StatusOK, s.respuesta())
+}
+
+func (s *ServidorWeb) manejarJugada(w http.ResponseWriter, r *http.Request) {
+	var mov motor.Movimiento
+	if err := json.NewDecoder(r.Body).Decode(&mov); err != nil {
+		resp := s.respuesta()
+		resp.Error = idioma.Tf("movimiento mal formado: %v", err)
+		escribirJSON(w, http.StatusBadRequest, resp)
+		return
+	}
+	// Solo hay alguien escuchando en movimientos durante el turno del jugador web.
+	peticion := peticionMovimiento{movimiento: mov, respuesta: make(chan RespuestaWeb, 1)}
+	select {
+	case s.movimientos <- peticion:
+	default:
+		resp := s.respuesta()
+		resp.Error = idioma.T("no es tu turno")
+		escribirJSON(w, http.StatusConflict, resp)
+		return
+	}
+	resp := <-peticion.respuesta
+	codigo := http.StatusOK
+	if resp.Error != "" {
+		codigo = http.StatusBadRequest
+	}
+	escribirJSON(w, codigo, resp)
+}
+
+func (s *ServidorWeb) manejarNueva(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	enCurso := s.enCurso
+	s.enCurso = true // Reservamos la partida nueva para que otra petición no empiece una más.
+	s.mu.Unlock()
+	if enCurso {
+		resp := s.respuesta()
+		resp.Error = idioma.T("la partida actual todavía no ha terminado")
+		escribirJSON(w, http.StatusConflict, resp)
+		return
+	}
+	s.empezarPartida()
+	escribirJSON(w, http.StatusOK, s.respuesta())
+}
+
+// responder contesta a una petición de movimiento con la foto publicada y el error, si lo hay.
+func (s *ServidorWeb) responder(peticion peticionMovimiento, err error) {
+	resp := s.respuesta()
+	if err != nil {
+		resp.Error = err.Error()
+	}
+	peticion.respuesta <- resp
+}
+
+func escribirJSON(w http.ResponseWriter, codigo int, v any) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(codigo)
+	json.NewEncoder(w).Encode(v)
+}
+
+// --- ESTRATEGIA: JUGADOR WEB
+
+// EstrategiaWeb espera los movimientos que llegan desde el navegador.
+type EstrategiaWeb struct {
+	servidor *ServidorWeb
+	partida  *motor.Partida
+
+	// La petición que ha cerrado el turno se contesta cuando el motor lo termina
+	// (robo incluido), para que el navegador vea su turno completo.
+	pendiente    *peticionMovimiento
+	errPendiente error
+}
+
+// contestar responde a la petición que cerró el último turno, si la hay.
+func (e *EstrategiaWeb) contestar() {
+	if e.pendiente != nil {
+		e.servidor.responder(*e.pendiente, e.errPendiente)
+		e.pendiente, e.errPendiente = nil, nil
+	}
+}
+
+func (e *EstrategiaWeb) JugarTurno(jugador *motor.Jugador, mesa [][]mazo.Pieza) [][]mazo.Pieza {
+	// Igual que en la consola, la mano se muestra en el orden elegido por el jugador.
+	reglas.OrdenarMano(jugador.Mano, jugador.OrdenMano)
+	e.servidor.publicar(e.partida.Estado(jugador), true)
+	for {
+		var peticion peticionMovimiento
+		select {
+		case <-e.servidor.cerrado:
+			// Sin navegador que responda, el turno queda como estaba.
+			return mesa
+		case peticion = <-e.servidor.movimientos:
+		}
+		nueva, err := e.partida.Reglas.AplicarMovimiento(jugador, mesa, peticion.movimiento)
+		if err != nil {
+			if e.partida.IntentoInvalido(jugador) {
+				e.pendiente = &peticion
+				e.errPendiente = fmt.Errorf(idioma.T("%v; has robado %d ficha(s) de penalización"), err, e.partida.Reglas.PenalizacionInvalida)
+				return mesa
+			}
+			e.servidor.responder(peticion, err)
+			continue
+		}
+		// La jugada ya aplicada se ve mientras el motor valida y cierra el turno.
+		e.servidor.publicar(e.partida.EstadoDuranteTurno(jugador, nueva), false)
+		e.pendiente = &peticion
+		return nueva
+	}
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"com.github/hapkiduki/rummikub/idioma"
+)
+
+func pedirWeb(t *testing.T, metodo, url, cuerpo string) (int, RespuestaWeb) {
+	t.Helper()
+	peticion, err := http.NewRequest(metodo, url, strings.NewReader(cuerpo))
+	if err != nil {
+		t.Fatalf("No se pudo crear la petición: %v", err)
+	}
+	respuesta, err := http.DefaultClient.Do(peticion)
+	if err != nil {
+		t.Fatalf("Error en %s %s: %v", metodo, url, err)
+	}
+	defer respuesta.Body.Close()
+	var resp RespuestaWeb
+	if err := json.NewDecoder(respuesta.Body).Decode(&resp); err != nil {
+		t.Fatalf("Respuesta no es JSON válido: %v", err)
+	}
+	return respuesta.StatusCode, resp
+}
+
+func TestServidorWebTurnoDelNavegador(t *testing.T) {
+	servidorWeb, err := NuevoServidorWeb(2, OpcionesWeb{Silencioso: true})
+	if err != nil {
+		t.Fatalf("No se pudo crear el servidor web: %v", err)
+	}
+	defer servidorWeb.Cerrar()
+	ts := httptest.NewServer(servidorWeb.Handler())
+	defer ts.Close()
+
+	// El jugador web ocupa el primer asiento: su turno llega enseguida.
+	limite := time.Now().Add(5 * time.Second)
+	var resp RespuestaWeb
+	for !resp.EsTuTurno {
+		if time.Now().After(limite) {
+			t.Fatal("El turno del jugador web nunca llegó")
+		}
+		_, resp = pedirWeb(t, http.MethodGet, ts.URL+"/api/estado", "")
+		time.Sleep(10 * time.Millisecond)
+	}
+	if len(resp.Estado.Mano) != 14 {
+		t.Errorf("Se esperaban 14 fichas en la mano, hay %d", len(resp.Estado.Mano))
+	}
+
+	codigo, resp := pedirWeb(t, http.MethodPost, ts.URL+"/api/jugada", `{"accion":"jugar","indices":[0]}`)
+	if codigo != http.StatusBadRequest || resp.Error == "" {
+		t.Errorf("Una sola ficha debe rechazarse, se obtuvo %d %q", codigo, resp.Error)
+	}
+
+	codigo, resp = pedirWeb(t, http.MethodPost, ts.URL+"/api/jugada", `{"accion":"robar"}`)
+	if codigo != http.StatusOK {
+		t.Fatalf("Robar debería ser válido, se obtuvo %d %q", codigo, resp.Error)
+	}
+	if resp.EsTuTurno || len(resp.Estado.Mano) != 15 {
+		t.Errorf("Tras robar se esperaba fin de turno y 15 fichas, se obtuvo turno=%v y %d fichas", resp.EsTuTurno, len(resp.Estado.Mano))
+	}
+
+	// Tras Cerrar nadie espera movimientos del navegador.
+	servidorWeb.Cerrar()
+	codigo, _ = pedirWeb(t, http.MethodPost, ts.URL+"/api/jugada", `{"accion":"robar"}`)
+	if codigo != http.StatusConflict {
+		t.Errorf("Fuera de turno se esperaba %d, se obtuvo %d", http.StatusConflict, codigo)
+	}
+
+	pagina, err := http.Get(ts.URL + "/")
+	if err != nil || pagina.StatusCode != http.StatusOK {
+		t.Fatalf("No se pudo cargar la interfaz web: %v", err)
+	}
+	pagina.Body.Close()
+}
+
+func TestTextosWeb(t *testing.T) {
+	defer idioma.Usar(idioma.Actual())
+	idioma.Usar(idioma.Ingles)
+	textos := textosWeb()
+	if textos.Idioma != idioma.Ingles || textos.Textos["titulo"] != "Rummikub in Go" {
+		t.Errorf("Se esperaban los textos en inglés, pero fueron %v", textos)
+	}
+
+	// Cada texto que pide index.html tiene que estar en TextosWeb.
+	pagina, err := archivosWeb.ReadFile("web/index.html")
+	if err != nil {
+		t.Fatal(err)
+	}
+	claves := regexp.MustCompile(`data-texto="(\w+)"|\bt\("(\w+)"`).FindAllStringSubmatch(string(pagina), -1)
+	if len(claves) == 0 {
+		t.Fatal("Se esperaba que index.html pidiera textos")
+	}
+	for _, clave := range claves {
+		if c := clave[1] + clave[2]; textos.Textos[c] == "" {
+			t.Errorf("Se esperaba el texto %q en TextosWeb", c)
+		}
+	}
+}
@@ -0,0 +1,129 @@
+package externo
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"time"
+
+	"com.github/hapkiduki/rummikub/bots"
+	"com.github/hapkiduki/rummikub/idioma"
+	"com.github/hapkiduki/rummikub/mazo"
+	"com.github/hapkiduki/rummikub/motor"
+)
+
+// --- PRUEBAS DE CONFORMIDAD ---
+
+// ResultadoPrueba es el resultado de una de las comprobaciones de Comprobar. Err es
+// nil si el programa la ha superado.
+type ResultadoPrueba struct {
+	Nombre string
+	Err    error
+}
+
+// pruebaConformidad prepara una situación y comprueba cómo responde el programa.
+// Cada prueba lanza el programa de nuevo.
+type pruebaConformidad struct {
+	nombre string
+	probar func(e *Estrategia) error
+}
+
+var pruebasConformidad = []pruebaConformidad{
+	{"contesta a su turno con un movimiento legal", func(e *Estrategia) error {
+		return comprobarTurno(e, "R10 R11 R12 B1 K5 Y9", "", false)
+	}},
+	{"juega con la mesa ocupada después de abrir", func(e *Estrategia) error {
+		return comprobarTurno(e, "R6 K9 B2 J", "R3 R4 R5 | B7 Y7 K7", true)
+	}},
+	{"vuelve a contestar después de un error", func(e *Estrategia) error {
+		jugador := jugadorDePrueba("R1 B2 Y3")
+		estado := e.estado(jugador, nil)
+		if _, err := e.preguntar(Mensaje{Tipo: TipoTuTurno, Estado: &estado}); err != nil {
+			return err
+		}
+		_, err := e.preguntar(Mensaje{Tipo: TipoError, Error: idioma.T("movimiento rechazado por la prueba")})
+		return err
+	}},
+	{"juega una partida completa", func(e *Estrategia) error {
+		jugadores := []*motor.Jugador{
+			{Nombre: "externo", Estrategia: e},
+			{Nombre: "novato", Estrategia: bots.EstrategiaNovato{Silencioso: true}},
+		}
+		e.Partida = motor.NuevaPartida(jugadores, motor.OpcionesPartida{Rand: rand.New(rand.NewSource(1)), Silenciosa: true})
+		e.Partida.Jugar()
+		return e.Err()
+	}},
+	{"sale al recibir el fin de la partida", func(e *Estrategia) error {
+		if err := comprobarTurno(e, "R1 B2 Y3", "", false); err != nil {
+			return err
+		}
+		return e.Cerrar()
+	}},
+}
+
+// Comprobar lanza el programa en varias situaciones y comprueba que sigue el
+// protocolo: que contesta a tiempo, con mensajes bien formados y movimientos legales.
+// No juzga si juega bien o mal.
+func Comprobar(comando []string, tiempo time.Duration) []ResultadoPrueba {
+	resultados := make([]ResultadoPrueba, 0, len(pruebasConformidad))
+	for _, prueba := range pruebasConformidad {
+		e := &Estrategia{Comando: comando, Tiempo: tiempo, Intentos: 1, Silenciosa: true}
+		err := prueba.probar(e)
+		if err == nil {
+			err = e.Err()
+		}
+		e.detener()
+		resultados = append(resultados, ResultadoPrueba{Nombre: prueba.nombre, Err: err})
+	}
+	return resultados
+}
+
+// comprobarTurno pregunta al programa con la mano y la mesa indicadas (en notación
+// corta, con las jugadas de la mesa separadas por '|') y comprueba que el movimiento
+// que devuelve es legal con el reglamento estándar.
+func comprobarTurno(e *Estrategia, mano, mesa string, abierto bool) error {
+	jugador := jugadorDePrueba(mano)
+	jugador.HaHechoPrimeraJugada = abierto
+	jugadas := mesaDePrueba(mesa)
+	estado := e.estado(jugador, jugadas)
+	mov, err := e.preguntar(Mensaje{Tipo: TipoTuTurno, Estado: &estado})
+	if err != nil {
+		return err
+	}
+	if _, err := (motor.ReglasCasa{}).AplicarMovimiento(jugador, jugadas, mov); err != nil {
+		return fmt.Errorf(idioma.T("movimiento ilegal %+v: %w"), mov, err)
+	}
+	return nil
+}
+
+// jugadorDePrueba crea un jugador con la mano indicada, con IDs distintos como en
+// una partida de verdad.
+func jugadorDePrueba(mano string) *motor.Jugador {
+	return &motor.Jugador{Nombre: "externo", Mano: fichasDePrueba(mano, 1)}
+}
+
+// mesaDePrueba lee una mesa con las jugadas separadas por '|'.
+func mesaDePrueba(texto string) [][]mazo.Pieza {
+	mesa := make([][]mazo.Pieza, 0)
+	if texto == "" {
+		return mesa
+	}
+	for i, parte := range strings.Split(texto, "|") {
+		mesa = append(mesa, fichasDePrueba(parte, 100*(i+1)))
+	}
+	return mesa
+}
+
+// fichasDePrueba lee las fichas de una situación de prueba y las numera desde
+// primerID. Las situaciones están escritas en este archivo, así que un error de
+// notación es un fallo de programación.
+func fichasDePrueba(texto string, primerID int) []mazo.Pieza {
+	fichas, err := mazo.ParsearFichas(texto)
+	if err != nil {
+		panic(err)
+	}
+	for i := range fichas {
+		fichas[i].ID = primerID + i
+	}
+	return fichas
+}
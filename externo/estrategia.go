@@ -0,0 +1,301 @@
+// Package externo permite que un programa aparte juegue como estrategia. El
+// programa recibe el estado de la partida por su entrada estándar y contesta con su
+// movimiento por la salida estándar, un mensaje JSON por línea.
+package externo
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"time"
+
+	"com.github/hapkiduki/rummikub/idioma"
+	"com.github/hapkiduki/rummikub/mazo"
+	"com.github/hapkiduki/rummikub/motor"
+)
+
+// --- PROTOCOLO ---
+//
+// Cada mensaje es un objeto JSON en una sola línea terminada en '\n', con el mismo
+// formato que el modo servidor. Todos los mensajes tienen un campo "tipo".
+//
+// Motor → programa (por su entrada estándar):
+//
+//	{"tipo":"tu_turno","estado":{...}}  el programa debe contestar con una "jugada"
+//	{"tipo":"error","error":"..."}      el movimiento anterior no era legal; se espera otra "jugada"
+//	{"tipo":"fin","estado":{...}}       la partida ha terminado; el programa debe salir
+//
+// Programa → motor (por su salida estándar):
+//
+//	{"tipo":"jugada","movimiento":{"accion":"jugar","indices":[0,4,8]}}
+//	{"tipo":"jugada","movimiento":{"accion":"anadir","indices":[3],"jugada":1}}
+//	{"tipo":"jugada","movimiento":{"accion":"robar"}}
+//
+// El estado es un motor.EstadoPartida con la mano del jugador; los índices se refieren
+// a esa mano. Cada respuesta debe llegar antes de Estrategia.Tiempo. Lo que el programa
+// escriba en su salida de error se muestra tal cual, salvo en modo silencioso.
+
+// Tipos de mensaje del protocolo.
+const (
+	TipoTuTurno = "tu_turno"
+	TipoJugada  = "jugada"
+	TipoError   = "error"
+	TipoFin     = "fin"
+)
+
+// Mensaje es la unidad del protocolo.
+type Mensaje struct {
+	Tipo       string               `json:"tipo"`
+	Estado     *motor.EstadoPartida `json:"estado,omitempty"`
+	Movimiento *motor.Movimiento    `json:"movimiento,omitempty"`
+	Error      string               `json:"error,omitempty"`
+}
+
+// TiempoPorDefecto es lo que se espera cada respuesta si Estrategia.Tiempo es 0.
+const TiempoPorDefecto = 5 * time.Second
+
+// IntentosPorDefecto son los movimientos ilegales seguidos que se aceptan en un turno
+// si Estrategia.Intentos es 0. Después, el jugador roba.
+const IntentosPorDefecto = 3
+
+// --- ESTRATEGIA: PROGRAMA EXTERNO ---
+
+// Estrategia juega los turnos de un jugador preguntando a un programa externo. El
+// programa se arranca en el primer turno y sigue vivo hasta Cerrar. Cada movimiento
+// que devuelve pasa por ReglasCasa.AplicarMovimiento, así que no puede hacer trampas.
+// Si el programa no contesta a tiempo, se cae o rompe el protocolo, se detiene y el
+// jugador roba en todos sus turnos; Err dice qué pasó.
+type Estrategia struct {
+	Comando    []string       // El programa y sus argumentos.
+	Tiempo     time.Duration  // Máximo para cada respuesta; 0 usa TiempoPorDefecto.
+	Intentos   int            // Movimientos ilegales por turno antes de robar; 0 usa IntentosPorDefecto.
+	Partida    *motor.Partida // Para enviar el estado completo y aplicar las reglas de la casa; puede ser nil.
+	Silenciosa bool           // Sin la salida de error del programa. Lo que pasa en el turno lo cuentan los observadores de Partida.
+
+	proceso *exec.Cmd
+	entrada io.WriteCloser
+	lineas  chan []byte
+	jugador *motor.Jugador // El del último turno, para el mensaje de fin.
+	err     error
+}
+
+// Nueva crea una estrategia que lanza el programa indicado con sus argumentos.
+func Nueva(comando ...string) *Estrategia {
+	return &Estrategia{Comando: comando}
+}
+
+// JugarTurno envía el estado al programa y aplica el movimiento que devuelve.
+func (e *Estrategia) JugarTurno(jugador *motor.Jugador, mesa [][]mazo.Pieza) [][]mazo.Pieza {
+	e.jugador = jugador
+	if e.err != nil {
+		e.avisar(motor.ProgramaDetenido{Turno: e.turno(), Jugador: jugador.Nombre})
+		return mesa
+	}
+	estado := e.estado(jugador, mesa)
+	mensaje := Mensaje{Tipo: TipoTuTurno, Estado: &estado}
+	for intento := 1; ; intento++ {
+		mov, err := e.preguntar(mensaje)
+		if err != nil {
+			e.fallar(err)
+			e.avisar(motor.ProgramaDetenido{Turno: e.turno(), Jugador: jugador.Nombre, Motivo: e.err.Error()})
+			return mesa
+		}
+		nueva, err := e.reglasCasa().AplicarMovimiento(jugador, mesa, mov)
+		if err == nil {
+			return nueva
+		}
+		e.avisar(motor.MovimientoRechazado{Turno: e.turno(), Jugador: jugador.Nombre, Motivo: err.Error()})
+		if e.Partida.IntentoInvalido(jugador) || intento >= e.intentos() {
+			return mesa
+		}
+		mensaje = Mensaje{Tipo: TipoError, Error: err.Error()}
+	}
+}
+
+// Err devuelve el fallo que detuvo el programa, o nil si sigue funcionando.
+func (e *Estrategia) Err() error {
+	return e.err
+}
+
+// Cerrar avisa al programa de que la partida ha terminado y espera a que salga. Si
+// no sale a tiempo se detiene y se devuelve un error.
+func (e *Estrategia) Cerrar() error {
+	if e.proceso == nil {
+		return nil
+	}
+	fin := Mensaje{Tipo: TipoFin}
+	if e.jugador != nil && e.Partida != nil {
+		estado := e.Partida.Estado(e.jugador)
+		fin.Estado = &estado
+	}
+	e.enviar(fin)
+	e.entrada.Close()
+	go descartar(e.lineas)
+	terminado := make(chan error, 1)
+	go func() { terminado <- e.proceso.Wait() }()
+	var err error
+	select {
+	case err = <-terminado:
+	case <-time.After(e.tiempo()):
+		e.proceso.Process.Kill()
+		<-terminado
+		err = errors.New(idioma.T("el programa no ha salido al recibir el fin de la partida"))
+	}
+	e.proceso = nil
+	return err
+}
+
+// estado construye lo que se envía al programa. Sin Partida solo se conocen la mesa
+// y el propio jugador.
+func (e *Estrategia) estado(jugador *motor.Jugador, mesa [][]mazo.Pieza) motor.EstadoPartida {
+	if e.Partida != nil {
+		estado := e.Partida.Estado(jugador)
+		estado.Mesa = motor.CopiarMesa(mesa)
+		return estado
+	}
+	return motor.EstadoPartida{
+		JugadorActual: jugador.Nombre,
+		Mesa:          motor.CopiarMesa(mesa),
+		Jugadores:     []motor.EstadoJugador{{Nombre: jugador.Nombre, Fichas: len(jugador.Mano), HaHechoPrimeraJugada: jugador.HaHechoPrimeraJugada}},
+		Mano:          append([]mazo.Pieza(nil), jugador.Mano...),
+	}
+}
+
+// preguntar envía un mensaje y espera la jugada que contesta el programa.
+func (e *Estrategia) preguntar(m Mensaje) (motor.Movimiento, error) {
+	if e.proceso == nil {
+		if err := e.iniciar(); err != nil {
+			return motor.Movimiento{}, err
+		}
+	}
+	if err := e.enviar(m); err != nil {
+		return motor.Movimiento{}, err
+	}
+	select {
+	case linea, ok := <-e.lineas:
+		if !ok {
+			return motor.Movimiento{}, errors.New(idioma.T("el programa ha terminado sin contestar"))
+		}
+		var respuesta Mensaje
+		if err := json.Unmarshal(linea, &respuesta); err != nil {
+			return motor.Movimiento{}, fmt.Errorf(idioma.T("mensaje mal formado: %v"), err)
+		}
+		if respuesta.Tipo != TipoJugada || respuesta.Movimiento == nil {
+			return motor.Movimiento{}, errors.New(idioma.T("se esperaba un mensaje de tipo 'jugada'"))
+		}
+		return *respuesta.Movimiento, nil
+	case <-time.After(e.tiempo()):
+		return motor.Movimiento{}, fmt.Errorf(idioma.T("el programa no ha contestado en %s"), e.tiempo())
+	}
+}
+
+// iniciar lanza el programa y empieza a leer su salida línea a línea.
+func (e *Estrategia) iniciar() error {
+	if len(e.Comando) == 0 {
+		return errors.New(idioma.T("falta el programa que debe jugar"))
+	}
+	proceso := exec.Command(e.Comando[0], e.Comando[1:]...)
+	if !e.Silenciosa {
+		proceso.Stderr = os.Stderr
+	}
+	entrada, err := proceso.StdinPipe()
+	if err != nil {
+		return err
+	}
+	salida, err := proceso.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	if err := proceso.Start(); err != nil {
+		return err
+	}
+	e.proceso, e.entrada = proceso, entrada
+	e.lineas = make(chan []byte)
+	go func() {
+		defer close(e.lineas)
+		scanner := bufio.NewScanner(salida)
+		scanner.Buffer(nil, 1<<20)
+		for scanner.Scan() {
+			e.lineas <- append([]byte(nil), scanner.Bytes()...)
+		}
+	}()
+	return nil
+}
+
+func (e *Estrategia) enviar(m Mensaje) error {
+	datos, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+	_, err = e.entrada.Write(append(datos, '\n'))
+	return err
+}
+
+// fallar guarda el error y detiene el programa: ya no se le pregunta nada más.
+func (e *Estrategia) fallar(err error) {
+	e.err = err
+	e.detener()
+}
+
+// detener mata el programa si sigue en marcha.
+func (e *Estrategia) detener() {
+	if e.proceso == nil {
+		return
+	}
+	e.entrada.Close()
+	e.proceso.Process.Kill()
+	go descartar(e.lineas)
+	e.proceso.Wait()
+	e.proceso = nil
+}
+
+// descartar lee lo que quede en el canal para que el lector pueda terminar.
+func descartar(lineas <-chan []byte) {
+	for range lineas {
+	}
+}
+
+func (e *Estrategia) tiempo() time.Duration {
+	if e.Tiempo <= 0 {
+		return TiempoPorDefecto
+	}
+	return e.Tiempo
+}
+
+func (e *Estrategia) intentos() int {
+	if e.Intentos <= 0 {
+		return IntentosPorDefecto
+	}
+	return e.Intentos
+}
+
+// avisar cuenta lo que le pasa al programa a los observadores de Partida. Sin Partida
+// no hay observadores: lo cuenta un Narrador, salvo en silencio.
+func (e *Estrategia) avisar(evento motor.Evento) {
+	switch {
+	case e.Partida != nil:
+		e.Partida.Emitir(evento)
+	case !e.Silenciosa:
+		motor.Narrador{}.Notificar(nil, evento)
+	}
+}
+
+// turno es el turno de Partida para los eventos, o 0 si no hay partida.
+func (e *Estrategia) turno() int {
+	if e.Partida == nil {
+		return 0
+	}
+	return e.Partida.Turno
+}
+
+// reglasCasa devuelve las reglas de Partida, o el reglamento estándar si no hay partida.
+func (e *Estrategia) reglasCasa() motor.ReglasCasa {
+	if e.Partida == nil {
+		return motor.ReglasCasa{}
+	}
+	return e.Partida.Reglas
+}
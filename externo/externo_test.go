@@ -0,0 +1,166 @@
+package externo
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math/rand"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"com.github/hapkiduki/rummikub/motor"
+)
+
+// variablePrueba hace que el binario de las pruebas se comporte como un bot externo.
+// Así las pruebas no dependen de compilar otro programa.
+const variablePrueba = "RUMMIKUB_BOT_PRUEBA"
+
+func TestMain(m *testing.M) {
+	comportamiento := os.Getenv(variablePrueba)
+	if comportamiento == "" {
+		os.Exit(m.Run())
+	}
+	if comportamiento == "referencia" {
+		if err := JugarReferencia(os.Stdin, os.Stdout); err != nil {
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+	scanner := bufio.NewScanner(os.Stdin)
+	scanner.Buffer(nil, 1<<20)
+	for scanner.Scan() {
+		switch comportamiento {
+		case "dormilon":
+			time.Sleep(time.Minute)
+		case "roto":
+			fmt.Println("esto no es JSON")
+		case "tramposo":
+			fmt.Println(`{"tipo":"jugada","movimiento":{"accion":"jugar","indices":[0]}}`)
+		}
+	}
+	os.Exit(0)
+}
+
+// botDePrueba devuelve una estrategia que lanza el binario de las pruebas con el
+// comportamiento indicado.
+func botDePrueba(t *testing.T, comportamiento string) *Estrategia {
+	t.Setenv(variablePrueba, comportamiento)
+	return &Estrategia{Comando: []string{os.Args[0]}, Tiempo: 2 * time.Second, Silenciosa: true}
+}
+
+func TestEstrategiaExterna(t *testing.T) {
+	casosDePrueba := []struct {
+		nombre         string
+		comportamiento string
+		comando        []string
+		tiempo         time.Duration
+		fichasEnMano   int
+		falla          bool
+	}{
+		{nombre: "El bot de referencia abre con una escalera", comportamiento: "referencia", fichasEnMano: 1},
+		{nombre: "Un movimiento ilegal no cambia la mano ni detiene el programa", comportamiento: "tramposo", fichasEnMano: 4},
+		{nombre: "Una respuesta mal formada detiene el programa", comportamiento: "roto", fichasEnMano: 4, falla: true},
+		{nombre: "Un programa que no contesta a tiempo se detiene", comportamiento: "dormilon", tiempo: 100 * time.Millisecond, fichasEnMano: 4, falla: true},
+		{nombre: "Un programa que no existe no puede jugar", comando: []string{"/no/existe/bot"}, fichasEnMano: 4, falla: true},
+	}
+
+	for _, tc := range casosDePrueba {
+		t.Run(tc.nombre, func(t *testing.T) {
+			e := botDePrueba(t, tc.comportamiento)
+			if tc.comando != nil {
+				e.Comando = tc.comando
+			}
+			if tc.tiempo > 0 {
+				e.Tiempo = tc.tiempo
+			}
+			defer e.Cerrar()
+			jugador := jugadorDePrueba("R10 R11 R12 B1")
+			e.JugarTurno(jugador, nil)
+			if len(jugador.Mano) != tc.fichasEnMano {
+				t.Errorf("Se esperaban %d fichas en la mano, pero hay %d", tc.fichasEnMano, len(jugador.Mano))
+			}
+			if falla := e.Err() != nil; falla != tc.falla {
+				t.Errorf("Se esperaba fallo=%v, pero el error es %v", tc.falla, e.Err())
+			}
+		})
+	}
+}
+
+func TestAvisosDeLaEstrategia(t *testing.T) {
+	casosDePrueba := []struct {
+		nombre         string
+		comportamiento string
+		eventos        []string
+	}{
+		{nombre: "Un movimiento ilegal se avisa como evento", comportamiento: "tramposo", eventos: []string{"movimiento_rechazado"}},
+		{nombre: "Un programa roto se avisa al fallar y en cada turno que pierde", comportamiento: "roto", eventos: []string{"programa_detenido", "programa_detenido"}},
+	}
+
+	for _, tc := range casosDePrueba {
+		t.Run(tc.nombre, func(t *testing.T) {
+			var eventos []string
+			var salida strings.Builder
+			registrar := motor.ObservadorFunc(func(_ *motor.Partida, e motor.Evento) {
+				switch e.(type) {
+				case motor.MovimientoRechazado, motor.ProgramaDetenido:
+					eventos = append(eventos, e.Tipo())
+				}
+			})
+			jugadores := []*motor.Jugador{{Nombre: "externo"}, {Nombre: "otro"}}
+			partida := motor.NuevaPartida(jugadores, motor.OpcionesPartida{Rand: rand.New(rand.NewSource(1)), Silenciosa: true, Observadores: []motor.Observador{registrar, motor.Narrador{Salida: &salida}}})
+			e := botDePrueba(t, tc.comportamiento)
+			e.Partida, e.Intentos = partida, 1
+			defer e.Cerrar()
+			e.JugarTurno(jugadores[0], partida.Mesa)
+			if e.Err() != nil {
+				// Con el programa detenido, el siguiente turno se pierde.
+				e.JugarTurno(jugadores[0], partida.Mesa)
+			}
+			if !reflect.DeepEqual(eventos, tc.eventos) {
+				t.Errorf("Se esperaban los eventos %v, pero fueron %v", tc.eventos, eventos)
+			}
+			if !strings.Contains(salida.String(), "externo") {
+				t.Errorf("Se esperaba que el narrador contara el aviso, pero escribió %q", salida.String())
+			}
+		})
+	}
+}
+
+func TestComprobar(t *testing.T) {
+	casosDePrueba := []struct {
+		nombre         string
+		comportamiento string
+		cumple         bool
+	}{
+		{nombre: "El bot de referencia cumple el protocolo", comportamiento: "referencia", cumple: true},
+		{nombre: "Un bot tramposo no pasa las pruebas", comportamiento: "tramposo", cumple: false},
+	}
+
+	for _, tc := range casosDePrueba {
+		t.Run(tc.nombre, func(t *testing.T) {
+			e := botDePrueba(t, tc.comportamiento)
+			cumple := true
+			for _, r := range Comprobar(e.Comando, e.Tiempo) {
+				if r.Err != nil {
+					cumple = false
+					if tc.cumple {
+						t.Errorf("Se esperaba que pasara '%s', pero falló: %v", r.Nombre, r.Err)
+					}
+				}
+			}
+			if cumple != tc.cumple {
+				t.Errorf("Se esperaba cumple=%v, pero fue %v", tc.cumple, cumple)
+			}
+		})
+	}
+}
+
+func TestJugarReferenciaSinEstado(t *testing.T) {
+	entrada := strings.NewReader(`{"tipo":"tu_turno"}` + "\n")
+	if err := JugarReferencia(entrada, io.Discard); err == nil {
+		t.Errorf("Se esperaba un error si el turno no trae el estado")
+	}
+}
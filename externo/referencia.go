@@ -0,0 +1,74 @@
+package externo
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"com.github/hapkiduki/rummikub/idioma"
+	"com.github/hapkiduki/rummikub/motor"
+	"com.github/hapkiduki/rummikub/reglas"
+)
+
+// --- BOT DE REFERENCIA ---
+
+// JugarReferencia es un bot completo que habla el protocolo: lee los mensajes de
+// entrada y escribe sus jugadas en salida hasta recibir el fin de la partida. Sirve
+// de ejemplo para escribir bots en otros lenguajes; cmd/bot-referencia lo convierte
+// en un programa.
+func JugarReferencia(entrada io.Reader, salida io.Writer) error {
+	scanner := bufio.NewScanner(entrada)
+	scanner.Buffer(nil, 1<<20)
+	codificador := json.NewEncoder(salida)
+	for scanner.Scan() {
+		var m Mensaje
+		if err := json.Unmarshal(scanner.Bytes(), &m); err != nil {
+			return fmt.Errorf(idioma.T("mensaje mal formado: %v"), err)
+		}
+		var mov motor.Movimiento
+		switch m.Tipo {
+		case TipoTuTurno:
+			if m.Estado == nil {
+				return fmt.Errorf(idioma.T("el mensaje '%s' no trae el estado"), m.Tipo)
+			}
+			mov = elegirMovimiento(*m.Estado)
+		case TipoError:
+			// Lo que propusimos no era legal: robar siempre lo es.
+			mov = motor.Movimiento{Accion: motor.MovimientoRobar}
+		case TipoFin:
+			return nil
+		default:
+			continue
+		}
+		if err := codificador.Encode(Mensaje{Tipo: TipoJugada, Movimiento: &mov}); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
+
+// elegirMovimiento baja la mejor jugada de la mano (la de más valor si aún no ha
+// abierto) o, tras abrir, añade una ficha a la mesa. Si no puede hacer nada, roba.
+func elegirMovimiento(estado motor.EstadoPartida) motor.Movimiento {
+	abierto := false
+	for _, j := range estado.Jugadores {
+		if j.Nombre == estado.JugadorActual {
+			abierto = j.HaHechoPrimeraJugada
+		}
+	}
+	jugada := reglas.MejorJugada(reglas.JugadasPosibles(estado.Mano), !abierto)
+	if jugada != nil && (abierto || reglas.CalcularValorJugada(jugada) >= 30) {
+		return motor.Movimiento{Accion: motor.MovimientoJugar, Indices: reglas.IndicesEnMano(estado.Mano, jugada)}
+	}
+	if abierto {
+		for i, ficha := range estado.Mano {
+			for j, jugadaEnMesa := range estado.Mesa {
+				if reglas.SePuedeAnadirFicha(jugadaEnMesa, ficha) {
+					return motor.Movimiento{Accion: motor.MovimientoAnadir, Indices: []int{i}, Jugada: j}
+				}
+			}
+		}
+	}
+	return motor.Movimiento{Accion: motor.MovimientoRobar}
+}
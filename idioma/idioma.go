@@ -0,0 +1,121 @@
+// Package idioma traduce los textos del juego. Los textos se escriben en español en
+// el código y T los traduce al idioma activo.
+package idioma
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// --- IDIOMAS ---
+//
+// Cada idioma es un catálogo que va del texto en español (sin los saltos de línea ni
+// espacios de los extremos) a su traducción, con los mismos verbos de formato. Para
+// añadir un idioma basta con crear su catálogo y registrarlo con Registrar.
+
+// Idioma es un código de idioma de dos letras, como "es" o "en".
+type Idioma string
+
+const (
+	Espanol Idioma = "es"
+	Ingles  Idioma = "en"
+)
+
+// catalogos guarda las traducciones de cada idioma. El español no necesita catálogo.
+var catalogos = map[Idioma]map[string]string{
+	Ingles: mensajesIngles,
+}
+
+// idiomaActual es el idioma en que se muestran los textos. main lo fija al arrancar.
+var idiomaActual = Espanol
+
+// mu protege idiomaActual y catalogos: las partidas traducen desde sus goroutines.
+var mu sync.RWMutex
+
+// Usar cambia el idioma en que se muestran los textos. Debe llamarse antes de
+// empezar a jugar, no durante una partida.
+func Usar(idioma Idioma) {
+	mu.Lock()
+	defer mu.Unlock()
+	idiomaActual = idioma
+}
+
+// Actual devuelve el idioma en que se muestran los textos.
+func Actual() Idioma {
+	mu.RLock()
+	defer mu.RUnlock()
+	return idiomaActual
+}
+
+// Registrar añade (o sustituye) el catálogo de un idioma.
+func Registrar(idioma Idioma, mensajes map[string]string) {
+	mu.Lock()
+	defer mu.Unlock()
+	catalogos[idioma] = mensajes
+}
+
+// catalogo devuelve el catálogo de un idioma; el español no tiene.
+func catalogo(idioma Idioma) (map[string]string, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+	mensajes, ok := catalogos[idioma]
+	return mensajes, ok
+}
+
+// Elegir decide el idioma a partir del flag -idioma o, si está vacío, de las
+// variables de entorno LC_ALL, LC_MESSAGES y LANG (por ejemplo en_US.UTF-8).
+func Elegir(valor string) (Idioma, error) {
+	if valor != "" {
+		idioma := Idioma(strings.ToLower(valor))
+		if _, ok := catalogo(idioma); ok || idioma == Espanol {
+			return idioma, nil
+		}
+		return Espanol, fmt.Errorf(T("idioma desconocido '%s' (disponibles: %s)"), valor, strings.Join(Disponibles(), ", "))
+	}
+	for _, variable := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		if entorno := os.Getenv(variable); len(entorno) >= 2 {
+			idioma := Idioma(strings.ToLower(entorno[:2]))
+			if _, ok := catalogo(idioma); ok {
+				return idioma, nil
+			}
+			return Espanol, nil
+		}
+	}
+	return Espanol, nil
+}
+
+// Disponibles devuelve los códigos de todos los idiomas, empezando por el español.
+func Disponibles() []string {
+	mu.RLock()
+	otros := make([]string, 0, len(catalogos))
+	for idioma := range catalogos {
+		otros = append(otros, string(idioma))
+	}
+	mu.RUnlock()
+	sort.Strings(otros)
+	return append([]string{string(Espanol)}, otros...)
+}
+
+// T traduce un texto al idioma activo. Los saltos de línea y espacios de los extremos
+// se conservan y no forman parte de la clave. Si falta la traducción, se usa el español.
+func T(texto string) string {
+	mensajes, ok := catalogo(Actual())
+	if !ok {
+		return texto
+	}
+	clave := strings.TrimSpace(texto)
+	traduccion, ok := mensajes[clave]
+	if !ok || clave == "" {
+		return texto
+	}
+	inicio := strings.Index(texto, clave)
+	return texto[:inicio] + traduccion + texto[inicio+len(clave):]
+}
+
+// Tf traduce un formato y le aplica los argumentos, como fmt.Sprintf.
+func Tf(formato string, args ...any) string {
+	return fmt.Sprintf(T(formato), args...)
+}
@@ -0,0 +1,125 @@
+package idioma
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// funcionesTraducidas indica, para cada función que traduce su texto, la posición
+// del argumento que se traduce.
+var funcionesTraducidas = map[string]int{"T": 0, "Tf": 0, "anunciar": 0, "linea": 0}
+
+// TestCatalogoIngles busca en el código de todo el módulo los textos que se traducen
+// y comprueba que el catálogo en inglés los tiene todos.
+func TestCatalogoIngles(t *testing.T) {
+	var archivos []string
+	err := filepath.WalkDir("..", func(ruta string, d fs.DirEntry, err error) error {
+		if err == nil && !d.IsDir() && strings.HasSuffix(ruta, ".go") && !strings.HasSuffix(ruta, "_test.go") {
+			archivos = append(archivos, ruta)
+		}
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	fset := token.NewFileSet()
+	for _, archivo := range archivos {
+		f, err := parser.ParseFile(fset, archivo, nil, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ast.Inspect(f, func(n ast.Node) bool {
+			llamada, ok := n.(*ast.CallExpr)
+			if !ok {
+				return true
+			}
+			var nombre string
+			switch fn := llamada.Fun.(type) {
+			case *ast.Ident:
+				nombre = fn.Name
+			case *ast.SelectorExpr:
+				nombre = fn.Sel.Name
+			}
+			i, ok := funcionesTraducidas[nombre]
+			if !ok || len(llamada.Args) <= i {
+				return true
+			}
+			literal, ok := llamada.Args[i].(*ast.BasicLit)
+			if !ok || literal.Kind != token.STRING {
+				return true
+			}
+			texto, _ := strconv.Unquote(literal.Value)
+			comprobarTraduccion(t, fset.Position(literal.Pos()).String(), texto)
+			return true
+		})
+	}
+}
+
+// sinPalabras reconoce los formatos que no hace falta traducir: solo tienen verbos
+// como %s o %2d, colores de la terminal y signos.
+var sinPalabras = regexp.MustCompile(`^(%[-+# 0-9.]*[a-zA-Z%]|\x1b\[[0-9;]*m|[^\pL])*$`)
+
+func comprobarTraduccion(t *testing.T, donde, texto string) {
+	t.Helper()
+	clave := strings.TrimSpace(texto)
+	if sinPalabras.MatchString(clave) {
+		return
+	}
+	if _, ok := mensajesIngles[clave]; !ok {
+		t.Errorf("%s: falta la traducción al inglés de %q", donde, clave)
+	}
+}
+
+func TestTraducir(t *testing.T) {
+	defer func(anterior Idioma) { idiomaActual = anterior }(idiomaActual)
+	casosDePrueba := []struct {
+		idioma   Idioma
+		texto    string
+		esperado string
+	}{
+		{Espanol, "\n--- Fin de la Partida ---\n", "\n--- Fin de la Partida ---\n"},
+		{Ingles, "\n--- Fin de la Partida ---\n", "\n--- Game over ---\n"},
+		{Ingles, "  La mesa está vacía.", "  The table is empty."},
+		{Ingles, "un texto sin traducción", "un texto sin traducción"},
+		{Ingles, "", ""},
+	}
+	for _, tc := range casosDePrueba {
+		idiomaActual = tc.idioma
+		if resultado := T(tc.texto); resultado != tc.esperado {
+			t.Errorf("Se esperaba %q en %s para %q, pero se obtuvo %q", tc.esperado, tc.idioma, tc.texto, resultado)
+		}
+	}
+}
+
+func TestElegirIdioma(t *testing.T) {
+	casosDePrueba := []struct {
+		flag     string
+		lang     string
+		esperado Idioma
+		conError bool
+	}{
+		{"", "", Espanol, false},
+		{"", "en_US.UTF-8", Ingles, false},
+		{"", "es_ES.UTF-8", Espanol, false},
+		{"", "fr_FR.UTF-8", Espanol, false},
+		{"es", "en_US.UTF-8", Espanol, false},
+		{"EN", "", Ingles, false},
+		{"klingon", "", Espanol, true},
+	}
+	for _, tc := range casosDePrueba {
+		t.Setenv("LC_ALL", "")
+		t.Setenv("LC_MESSAGES", "")
+		t.Setenv("LANG", tc.lang)
+		idioma, err := Elegir(tc.flag)
+		if idioma != tc.esperado || (err != nil) != tc.conError {
+			t.Errorf("Se esperaba %s (error: %v) con -idioma=%q y LANG=%q, pero se obtuvo %s (%v)", tc.esperado, tc.conError, tc.flag, tc.lang, idioma, err)
+		}
+	}
+}
@@ -0,0 +1,339 @@
+package idioma
+
+// --- CATÁLOGO EN INGLÉS ---
+
+// mensajesIngles traduce al inglés todos los textos que pasan por T y Tf.
+// idioma_test.go comprueba que no falte ninguno.
+var mensajesIngles = map[string]string{
+	// main.go e idioma.go
+	"idioma desconocido '%s' (disponibles: %s)":                                                                      "unknown language '%s' (available: %s)",
+	"idioma de los mensajes: es o en (por defecto, el de LANG)":                                                      "message language: es or en (default: taken from LANG)",
+	"Error en la simulación: %v":                                                                                     "Simulation error: %v",
+	"Error en el torneo: %v":                                                                                         "Tournament error: %v",
+	"modo servidor: dirección TCP donde aceptar jugadores remotos (ej: :9000)":                                       "server mode: TCP address where remote players connect (e.g. :9000)",
+	"modo web: dirección HTTP donde servir la interfaz del navegador (ej: :8080)":                                    "web mode: HTTP address where the browser UI is served (e.g. :8080)",
+	"número de asientos en modo servidor o web (2-4); los vacíos los ocupan bots":                                    "number of seats in server or web mode (2-4); bots fill the empty ones",
+	"tiempo máximo que el servidor espera a jugadores remotos":                                                       "how long the server waits for remote players",
+	"tiempo que tiene un jugador remoto para cada turno; si se agota, un bot ocupa su asiento (0: sin límite)":       "how long a remote player has for each turn; when it runs out a bot takes their seat (0: no limit)",
+	"interfaz del jugador humano: tui (pantalla completa), texto o auto":                                             "human player interface: tui (full screen), texto (plain text) or auto",
+	"nivel de las pistas para el jugador humano: no, basica o completa":                                              "hint level for the human player: no, basica (basic) or completa (full)",
+	"regla de la casa: quien no juega roba hasta tener algo que jugar":                                               "house rule: a player who does not play keeps drawing until they can play",
+	"regla de la casa: fichas que roba quien intenta un movimiento inválido (0: ninguna)":                            "house rule: tiles drawn by a player who tries an invalid move (0: none)",
+	"variantes para tríos y cuartetas, separadas por comas: limitar-comodines, solo-comodines, cerrar-dos-comodines": "comma-separated variants for groups: limitar-comodines, solo-comodines, cerrar-dos-comodines",
+	"regla oficial: quien deja la mesa inválida recupera sus fichas y roba 3":                                        "official rule: a player who leaves the table invalid takes their tiles back and draws 3",
+	"No se pudo iniciar el servidor: %v":                                                                             "Could not start the server: %v",
+	"Error en el servidor: %v":                                                                                       "Server error: %v",
+	"No se pudo iniciar el servidor web: %v":                                                                         "Could not start the web server: %v",
+	"Abre http://%s en tu navegador para jugar.":                                                                     "Open http://%s in your browser to play.",
+	"Error en el servidor web: %v":                                                                                   "Web server error: %v",
+	"--- ¡Bienvenido a Rummikub en Go! ---":                                                                          "--- Welcome to Rummikub in Go! ---",
+	"--- ¡Comienza la Partida! ---":                                                                                  "--- The game begins! ---",
+	"--- Fin de la Partida ---":                                                                                      "--- Game over ---",
+
+	// types.go y orden.go
+	"Comodín 🃏":         "Joker 🃏",
+	"Rojo":              "Red",
+	"Azul":              "Blue",
+	"Amarillo":          "Yellow",
+	"Negro":             "Black",
+	"%s Ficha(%s, %d)":  "%s Tile(%s, %d)",
+	"Tú (Jugador 1)":    "You (Player 1)",
+	"por número":        "by number",
+	"jugadas sugeridas": "suggested melds",
+	"por color":         "by colour",
+
+	// partida.go
+	"tu primera jugada debe sumar 30 o más puntos, la tuya suma %d": "your first meld must be worth 30 points or more, yours is worth %d",
+	"no has hecho tu primera jugada: no has bajado ninguna ficha":   "you have not made your first meld: you placed no tiles",
+	"sobran %v y faltan %v":                                                     "%v are extra and %v are missing",
+	"la ficha %s tiene un ID que no le corresponde (%d)":                        "tile %s has an ID that does not belong to it (%d)",
+	"la ficha %s con ID %d aparece dos veces":                                   "tile %s with ID %d appears twice",
+	"solo puedes añadir una ficha a la vez":                                     "you can only add one tile at a time",
+	"debes hacer tu primera jugada antes de añadir fichas a la mesa":            "you must make your first meld before adding tiles to the table",
+	"la jugada %d no existe en la mesa":                                         "meld %d is not on the table",
+	"la ficha %s no encaja en la jugada %d: %w":                                 "tile %s does not fit meld %d: %w",
+	"la jugada %d es un trío con dos comodines y no admite más fichas":          "meld %d is a trio with two jokers and takes no more tiles",
+	"acción desconocida '%s'":                                                   "unknown action '%s'",
+	"no has seleccionado ninguna ficha":                                         "you have not selected any tile",
+	"el indice %d está fuera del rango de tu mano":                              "index %d is out of range for your hand",
+	"el indice %d fué seleccionado más de una vez":                              "index %d was selected more than once",
+	"regla de la casa: fichas que recibe cada jugador en el reparto (1-26)":     "house rule: tiles each player is dealt (1-26)",
+	"regla oficial: cada jugador saca una ficha y empieza el de la más alta":    "official rule: each player draws a tile and the highest starts",
+	"-fichas-iniciales debe estar entre 1 y 26":                                 "-fichas-iniciales must be between 1 and 26",
+	"partidas de la serie contra los bots; se suman los puntos de todas":        "games in the match against the bots; the scores of all of them add up",
+	"en una serie, empieza cada ronda el siguiente asiento en vez de sortearlo": "in a match, each round starts with the next seat instead of a draw",
+	"-rondas debe ser al menos 1":                                               "-rondas must be at least 1",
+	"=== Ronda %d de %d ===":                                                    "=== Round %d of %d ===",
+	"--- Marcador tras %d ronda(s) ---":                                         "--- Score after %d round(s) ---",
+	"%s: %d puntos":                                                             "%s: %d points",
+	"archivo JSON con las estadísticas de los perfiles":                         "JSON file with the profile statistics",
+	"falta el archivo de estadísticas":                                          "missing statistics file",
+	"Todavía no hay estadísticas.":                                              "No statistics yet.",
+	"--- Perfil: %s ---":                                                        "--- Profile: %s ---",
+	"Partidas: %d, victorias: %d (%.0f%%)":                                      "Games: %d, wins: %d (%.0f%%)",
+	"Puntos en la mano al acabar, de media: %.1f":                               "Average points left in hand: %.1f",
+	"Victorias más rápidas (turnos): %s":                                        "Fastest wins (turns): %s",
+	"Escalera más larga: %s (%d fichas)":                                        "Longest run: %s (%d tiles)",
+	"contra %-12s %d partidas, %d victorias":                                    "vs %-12s %d games, %d wins",
+	"Error al leer las estadísticas: %v":                                        "Error reading the statistics: %v",
+	"perfil del jugador humano en las estadísticas (vacío: no las guarda)":      "profile of the human player in the statistics (empty: do not record them)",
+	"No se pudieron leer las estadísticas: %v":                                  "Could not read the statistics: %v",
+	"No se pudieron guardar las estadísticas: %v":                               "Could not save the statistics: %v",
+	"Repartiendo fichas...":                                                     "Dealing tiles...",
+	"%s saca %s.":                                                               "%s draws %s.",
+	"Empieza %s.":                                                               "%s starts.",
+	"¡Todas las fichas han sido repartidas!":                                    "All tiles have been dealt!",
+	"¡Felicidades, %s! ¡Has ganado la partida!":                                 "Congratulations, %s! You won the game!",
+	"¡Se acabaron todas las fichas del mazo!":                                   "The pool has run out of tiles!",
+	"%s tiene %d puntos en su mano.":                                            "%s has %d points in hand.",
+	"¡Felicidades, %s! ¡Has ganado la partida con %d puntos!":                   "Congratulations, %s! You won the game with %d points!",
+	"%s ha usado %d pista(s).":                                                  "%s used %d hint(s).",
+	"la jugada %d de la mesa no es válida: %w":                                  "table meld %d is not valid: %w",
+	"la ficha %s ha pasado de la mesa a la mano":                                "tile %s was moved from the table to the hand",
+	"el trío %v tiene dos comodines y no admite más fichas":                     "the trio %v has two jokers and cannot take more tiles",
+	"no puedes tocar las jugadas de la mesa antes de tu primera jugada":         "you cannot touch the table melds before your first meld",
+	"%s deja la mesa inválida (%v): se deshace su turno.":                       "%s leaves the table invalid (%v): their turn is undone.",
+	"%s deja la mesa inválida (%v): recupera sus fichas.":                       "%s leaves the table invalid (%v): they take their tiles back.",
+	"%s no puede robar: no quedan fichas en el pozo.":                           "%s cannot draw: the pool is empty.",
+	"%s roba una ficha.":                                                        "%s draws a tile.",
+	"%s roba %d fichas.":                                                        "%s draws %d tiles.",
+	"%s roba %d ficha(s) de penalización.":                                      "%s draws %d penalty tile(s).",
+
+	// pistas.go
+	"nivel de pistas desconocido '%s' (usa no, basica o completa)":                          "unknown hint level '%s' (use no, basica or completa)",
+	"Aún no tienes ninguna jugada en la mano. Te conviene robar.":                           "You have no meld in your hand yet. You should draw.",
+	"Todavía no llegas a los 30 puntos para abrir: tu mejor jugada es %v y suma %d.":        "You cannot reach the 30 opening points yet: your best meld is %v, worth %d.",
+	"Todavía no llegas a los 30 puntos para abrir: tu mejor jugada suma %d.":                "You cannot reach the 30 opening points yet: your best meld is worth %d.",
+	"Todavía no llegas a los 30 puntos para abrir: tus mejores jugadas son %s y suman %d.":  "You cannot reach the 30 opening points yet: your best melds are %s, worth %d.",
+	"Todavía no llegas a los 30 puntos para abrir: juntando jugadas sumas %d.":              "You cannot reach the 30 opening points yet: your melds together are worth %d.",
+	"Puedes abrir bajando %s (%d puntos en total).":                                         "You can open by placing %s (%d points in total).",
+	"Ya puedes hacer tu primera jugada: entre %d jugadas de tu mano sumas 30 o más puntos.": "You can make your first move: %d melds in your hand add up to 30 points or more.",
+	"Puedes abrir con %v (%d puntos): juega los índices %v.":                                "You can open with %v (%d points): play indices %v.",
+	"Ya puedes hacer tu primera jugada: tienes %s que suma 30 o más puntos.":                "You can make your first meld: you have %s worth 30 points or more.",
+	"Puedes bajar %v: juega los índices %v.":                                                "You can place %v: play indices %v.",
+	"Tienes %s en la mano.":                                 "You have %s in your hand.",
+	"Puedes añadir tu %s (índice %d) a la jugada %d.":       "You can add your %s (index %d) to meld %d.",
+	"Una de tus fichas encaja en alguna jugada de la mesa.": "One of your tiles fits a meld on the table.",
+	"No encuentro ninguna jugada. Te conviene robar.":       "I cannot find any move. You should draw.",
+	"una cuarteta":              "a quartet",
+	"un trío":                   "a trio",
+	"una escalera de %d fichas": "a run of %d tiles",
+
+	// player.go
+	"Introduce el número de jugadores (2-4):":                       "Enter the number of players (2-4):",
+	"Número de jugadores inválido. Debe ser un número entre 2 y 4.": "Invalid number of players. It must be a number between 2 and 4.",
+	"Has robado un(a) %s.":                                          "You drew a %s.",
+	"--- Es tu turno, %s ---":                                       "--- Your turn, %s ---",
+	"--- Mesa de Juego ---":                                         "--- Table ---",
+	"La mesa está vacía.":                                           "The table is empty.",
+	"Rummikub en Go":                                                "Rummikub in Go",
+	"Mesa de juego":                                                 "Game table",
+	"Nueva jugada":                                                  "New meld",
+	"Arrastra fichas aquí (o haz clic en ellas) y pulsa «Bajar jugada». Para añadir una ficha a la mesa, arrástrala sobre la jugada.": "Drag tiles here (or click them) and press “Place meld”. To add a tile to the table, drag it onto the meld.",
+	"Tu mano":                        "Your hand",
+	"Bajar jugada":                   "Place meld",
+	"Robar ficha":                    "Draw tile",
+	"Nueva partida":                  "New game",
+	"Jugador":                        "Player",
+	"Fichas":                         "Tiles",
+	"Ha abierto":                     "Has opened",
+	"sí":                             "yes",
+	"no":                             "no",
+	"Fichas en el mazo: %d":          "Tiles in the pool: %d",
+	"Jugada %d":                      "Meld %d",
+	"Fin de la partida. Ganador: %s": "Game over. Winner: %s",
+	"Es tu turno.":                   "It is your turn.",
+	"Turno de %s...":                 "%s's turn...",
+	"Error: %s":                      "Error: %s",
+	"¡Felicidades! Has hecho tu primera jugada de %d puntos.": "Congratulations! You made your first meld, worth %d points.",
+	"Tu turno ha terminado.":                                  "Your turn is over.",
+	"Pista: %s":                                               "Hint: %s",
+	"Tu mano actual (%s):":                                    "Your current hand (%s):",
+	"--- Turno de %s ---":                                     "--- %s's turn ---",
+	"%s baja su primera jugada con %d puntos.":                "%s places their first meld, worth %d points.",
+	"%s juega: %v":                                            "%s plays: %v",
+	"%s no puede jugar.":                                      "%s cannot play.",
+	"%s añade un(a) %s a la jugada %d.":                       "%s adds a %s to meld %d.",
+	"%s está pensando...":                                     "%s is thinking...",
+
+	// notacion.go
+	"falta la ficha (usa, por ejemplo, R7 o J)":                           "missing tile (use, for example, R7 or J)",
+	"'%s': color desconocido '%c' (usa R, B, Y o K, o J para el comodín)": "'%s': unknown colour '%c' (use R, B, Y or K, or J for a joker)",
+	"'%s': falta el número después del color":                             "'%s': the number is missing after the colour",
+	"'%s': '%s' no es un número":                                          "'%s': '%s' is not a number",
+	"'%s': falta el ID de la ficha (por ejemplo, R7#12)":                  "'%s': the tile ID is missing (for example, R7#12)",
+	"'%s': '%s' no es un ID de ficha":                                     "'%s': '%s' is not a tile ID",
+	"'%s': el número debe estar entre 1 y 13":                             "'%s': the number must be between 1 and 13",
+	"no tienes %s en la mano":                                             "you do not have %s in your hand",
+
+	// comandos.go
+	"escribe un comando (help para ver la lista)":       "type a command (help lists them)",
+	"comando desconocido '%s'; ¿quisiste decir %s?":     "unknown command '%s'; did you mean %s?",
+	"comando desconocido '%s' (help para ver la lista)": "unknown command '%s' (help lists them)",
+	"uso: %s": "usage: %s",
+	"'%s' no es un número de jugada o posición válido":                                "'%s' is not a valid meld number or position",
+	"Las jugadas y las posiciones se cuentan desde 0, como se muestran en la mesa.":   "Melds and positions are counted from 0, as shown on the table.",
+	"ya has movido fichas en este turno: usa done para terminar o undo para deshacer": "you already moved tiles this turn: use done to finish or undo to take them back",
+	"%v; arréglalo o usa undo":                                                        "%v; fix it or use undo",
+	"no hay nada que deshacer en este turno":                                          "there is nothing to undo this turn",
+	"Movimiento deshecho.":                                                            "Move undone.",
+	"las pistas están desactivadas en esta partida":                                   "hints are disabled in this game",
+	"Tu mano se ordena %s.":                                                           "Your hand is now sorted %s.",
+	"Has bajado %s como jugada %d.":                                                   "You placed %s as meld %d.",
+	"La jugada %d queda así: %s.":                                                     "Meld %d is now: %s.",
+	"la jugada %d no tiene posición %d":                                               "meld %d has no position %d",
+	"la ficha ya está en esa jugada":                                                  "the tile is already in that meld",
+	"Has movido %s a la jugada %d.":                                                   "You moved %s to meld %d.",
+	"para partir la jugada %d la posición debe estar entre 1 y %d":                    "to split meld %d the position must be between 1 and %d",
+	"La jugada %d queda partida en %s y %s.":                                          "Meld %d is split into %s and %s.",
+	"Antes de terminar arregla las jugadas %s.":                                       "Fix melds %s before finishing.",
+	"no hay ninguna partida que guardar":                                              "there is no game to save",
+	"sigue una partida guardada con el comando save":                                  "resume a game saved with the save command",
+	"-cargar sigue una sola partida: no se puede usar con -rondas":                    "-cargar resumes a single game: it cannot be used with -rondas",
+	"No se pudo cargar la partida: %v":                                                "Could not load the game: %v",
+	"la partida guardada es de %d jugadores, no de %d":                                "the saved game has %d players, not %d",
+	"la partida guardada no indica bien de quién es el turno":                         "the saved game does not say correctly whose turn it is",
+	"la partida guardada no tiene las 106 fichas: %v":                                 "the saved game does not have the 106 tiles: %v",
+	"Partida guardada en %s.":                                                         "Game saved to %s.",
+	"Escribe un comando (help para ver la lista).":                                    "Type a command (help lists them).",
+	"No se puede: %v.":                                                                "Not allowed: %v.",
+	"Jugada %d: %s":                                                                   "Meld %d: %s",
+	"Comandos:":                                                                       "Commands:",
+	"play <fichas>":                                                                   "play <tiles>",
+	"add <ficha> to <jugada>":                                                         "add <tile> to <meld>",
+	"move <jugada>:<posición> to <jugada|new>":                                        "move <meld>:<position> to <meld|new>",
+	"split <jugada> at <posición>":                                                    "split <meld> at <position>",
+	"save [archivo]":                                                                  "save [file]",
+	"baja una jugada nueva, por índices o en notación (play 0,4,8 o play R7 R8 R9)":   "place a new meld, by indices or in notation (play 0,4,8 or play R7 R8 R9)",
+	"añade una ficha de tu mano a una jugada de la mesa (add B5 to 3)":                "add a tile from your hand to a table meld (add B5 to 3)",
+	"mueve una ficha de la mesa a otra jugada o a una nueva (move 2:4 to 5)":          "move a table tile to another meld or to a new one (move 2:4 to 5)",
+	"parte una jugada de la mesa en dos, antes de la posición indicada":               "split a table meld in two, before the given position",
+	"no bajas nada y robas una ficha: termina el turno":                               "place nothing and draw a tile: ends the turn",
+	"termina el turno (si no has bajado nada, robas)":                                 "end the turn (if you placed nothing, you draw)",
+	"deshace el último movimiento del turno":                                          "undo the last move of the turn",
+	"pide una pista":                                                                  "ask for a hint",
+	"cambia el orden en que se muestra tu mano":                                       "change how your hand is sorted",
+	"vuelve a mostrar la mesa y tu mano":                                              "show the table and your hand again",
+	"muestra los comandos que has escrito":                                            "list the commands you have typed",
+	"muestra esta ayuda":                                                              "show this help",
+	"guarda la partida como estaba al empezar tu turno (por defecto en rummikub-partida.json); se sigue con -cargar":                                  "save the game as it was when your turn began (default: rummikub-partida.json); resume it with -cargar",
+	"←↑↓→ mover · Espacio seleccionar · Enter bajar/añadir · Tab mano/mesa · o orden · p pista · u deshacer · r terminar/robar · : comando · q salir": "←↑↓→ move · Space select · Enter place/add · Tab hand/table · o order · p hint · u undo · r end/draw · : command · q quit",
+
+	// rules.go y validacion.go
+	"variante de grupos desconocida '%s' (usa limitar-comodines, solo-comodines o cerrar-dos-comodines)": "unknown group variant '%s' (use limitar-comodines, solo-comodines or cerrar-dos-comodines)",
+	"una jugada necesita al menos 3 fichas y esta tiene %d":                                              "a meld needs at least 3 tiles and this one has %d",
+	"un trío o cuarteta tiene como mucho 4 fichas y este tiene %d":                                       "a group has at most 4 tiles and this one has %d",
+	"%v no es una ficha del juego":                                                                       "%v is not a tile of the game",
+	"una jugada necesita al menos una ficha que no sea comodín":                                          "a meld needs at least one tile that is not a joker",
+	"en un trío todas las fichas llevan el mismo número, y %v no coincide":                               "all tiles in a group share the same number, and %v does not match",
+	"en un trío no se puede repetir color: %v":                                                           "a group cannot repeat a colour: %v",
+	"este trío tiene más comodines que fichas":                                                           "this group has more jokers than tiles",
+	"en una escalera todas las fichas son del mismo color, y %v no lo es":                                "all tiles in a run share the same colour, and %v does not",
+	"en una escalera no se puede repetir número: %v":                                                     "a run cannot repeat a number: %v",
+	"faltan %d comodín(es) para cubrir los huecos entre %v":                                              "%d more joker(s) needed to fill the gaps between %v",
+	"una escalera va del 1 al 13 y esta necesitaría %d fichas":                                           "a run goes from 1 to 13 and this one would need %d tiles",
+	"las fichas no forman un trío o escalera válido":                                                     "the tiles do not form a valid group or run",
+
+	// servidor.go y web.go
+	"mensaje mal formado: %v":                                                "malformed message: %v",
+	"no es tu turno":                                                         "it is not your turn",
+	"el nombre %q ya está en uso":                                            "the name %q is already taken",
+	"se esperaba un mensaje de tipo 'jugada'":                                "expected a message of type 'jugada'",
+	"%s se ha desconectado. Un bot ocupa su asiento.":                        "%s disconnected. A bot takes their seat.",
+	"A %s se le ha acabado el tiempo del turno. Un bot ocupa su asiento.":    "%s ran out of time for the turn. A bot takes their seat.",
+	"se te ha acabado el tiempo del turno: un bot ocupa tu asiento":          "you ran out of time for your turn: a bot takes your seat",
+	"número de jugadores inválido: %d (debe estar entre 2 y 4)":              "invalid number of players: %d (must be between 2 and 4)",
+	"Servidor de Rummikub escuchando en %s. Esperando hasta %d jugadores...": "Rummikub server listening on %s. Waiting for up to %d players...",
+	"%s se ha unido a la partida (%d/%d).":                                   "%s joined the game (%d/%d).",
+	"Se acabó el tiempo de espera. Los asientos libres los ocuparán bots.":   "Waiting time is over. Bots will fill the free seats.",
+	"la partida ya ha comenzado":                                             "the game has already started",
+	"el primer mensaje debe ser {\"tipo\":\"unirse\",\"nombre\":\"...\"}":    "the first message must be {\"tipo\":\"unirse\",\"nombre\":\"...\"}",
+	"Tú (Navegador)":                             "You (Browser)",
+	"movimiento mal formado: %v":                 "malformed move: %v",
+	"la partida actual todavía no ha terminado":  "the current game has not finished yet",
+	"%v; has robado %d ficha(s) de penalización": "%v; you drew %d penalty tile(s)",
+
+	// simulacion.go y torneo.go
+	"estrategia desconocida '%s' (disponibles: %s)":           "unknown strategy '%s' (available: %s)",
+	"se necesitan entre 2 y 4 estrategias, hay %d":            "between 2 and 4 strategies are needed, got %d",
+	"el número de partidas debe ser positivo":                 "the number of games must be positive",
+	"Partidas jugadas: %d":                                    "Games played: %d",
+	"Duración media: %.1f turnos":                             "Average length: %.1f turns",
+	"Mazo agotado: %d partidas (%.1f%%)":                      "Pool ran out: %d games (%.1f%%)",
+	"Participante":                                            "Participant",
+	"Victorias":                                               "Wins",
+	"% victorias":                                             "% wins",
+	"Puntos en mano":                                          "Points in hand",
+	"número de partidas a jugar":                              "number of games to play",
+	"estrategias separadas por comas, una por asiento (2-4):": "comma-separated strategies, one per seat (2-4):",
+	"partidas que se juegan a la vez":                         "games played at the same time",
+	"semilla para barajar; con la misma semilla se repiten las mismas partidas (0: aleatoria)": "shuffle seed; the same seed replays the same games (0: random)",
+	"Semilla: %d · tiempo: %s":                                                 "Seed: %d · time: %s",
+	"se necesitan al menos 2 estrategias, hay %d":                              "at least 2 strategies are needed, got %d",
+	"jugadores por mesa inválidos: %d (entre 2 y 4, y no más que estrategias)": "invalid players per table: %d (between 2 and 4, and no more than strategies)",
+	"el número de repartos debe ser positivo":                                  "the number of deals must be positive",
+	"la estrategia '%s' aparece más de una vez":                                "strategy '%s' appears more than once",
+	"el número de rondas debe ser positivo":                                    "the number of rounds must be positive",
+	"formato de torneo desconocido '%s' (usa liga o suizo)":                    "unknown tournament format '%s' (use liga or suizo)",
+	"Torneo (%s): %d partidas":                                                 "Tournament (%s): %d games",
+	"Estrategia":                                                               "Strategy",
+	"Partidas":                                                                 "Games",
+	"Puntos":                                                                   "Points",
+	"estrategias participantes separadas por comas":                            "comma-separated participating strategies",
+	"formato: liga (todos contra todos) o suizo":                               "format: liga (round robin) or suizo (Swiss)",
+	"jugadores por partida (2-4)":                                              "players per game (2-4)",
+	"repartos por encuentro; cada uno se juega en todas las permutaciones de asientos": "deals per encounter; each one is played in every seat permutation",
+	"rondas del formato suizo":                                  "rounds of the Swiss format",
+	"semilla base de los repartos (0: aleatoria)":               "base seed for the deals (0: random)",
+	"archivo donde guardar el resultado de cada partida en CSV": "file where the result of each game is saved as CSV",
+	"Semilla: %d":              "Seed: %d",
+	"Partidas guardadas en %s": "Games saved to %s",
+
+	// externo y probar-bot
+	"El programa de %s ha fallado (%v) y se ha detenido.":                             "%s's program failed (%v) and was stopped.",
+	"Movimiento inválido de %s: %v":                                                   "Invalid move by %s: %v",
+	"el programa no ha salido al recibir el fin de la partida":                        "the program did not exit after the end of the game",
+	"el programa ha terminado sin contestar":                                          "the program exited without answering",
+	"el programa no ha contestado en %s":                                              "the program did not answer within %s",
+	"falta el programa que debe jugar":                                                "missing the program that should play",
+	"el mensaje '%s' no trae el estado":                                               "the '%s' message has no state",
+	"movimiento rechazado por la prueba":                                              "move rejected by the test",
+	"movimiento ilegal %+v: %w":                                                       "illegal move %+v: %w",
+	"contesta a su turno con un movimiento legal":                                     "answers its turn with a legal move",
+	"juega con la mesa ocupada después de abrir":                                      "plays on a busy table after opening",
+	"vuelve a contestar después de un error":                                          "answers again after an error",
+	"juega una partida completa":                                                      "plays a whole game",
+	"sale al recibir el fin de la partida":                                            "exits at the end of the game",
+	"se esperaba nombre=comando, no '%s'":                                             "expected name=command, not '%s'",
+	"registra un programa externo como estrategia: nombre=comando (se puede repetir)": "register an external program as a strategy: name=command (repeatable)",
+	"tiempo máximo para cada respuesta del programa":                                  "maximum time for each answer of the program",
+	"FALLA %s: %v":               "FAIL  %s: %v",
+	"OK    %s":                   "OK    %s",
+	"%d pruebas fallidas":        "%d checks failed",
+	"Error al probar el bot: %v": "Error testing the bot: %v",
+
+	// registro de eventos
+	"archivo donde escribir todos los eventos de las partidas en JSON Lines": "file where every game event is written as JSON Lines",
+	"lo mismo que -registro":              "same as -registro",
+	"No se pudo escribir el registro: %v": "Could not write the log: %v",
+
+	// tui.go
+	"Para añadir a una jugada selecciona exactamente una ficha.": "To add to a meld, select exactly one tile.",
+	"Selecciona fichas con Espacio antes de bajar una jugada.":   "Select tiles with Space before placing a meld.",
+	"Comodines": "Jokers",
+	"Número %d": "Number %d",
+	"Sugerida":  "Suggested",
+	"\x1b[1m Rummikub en Go\x1b[0m — turno de %s — mazo: %d fichas": "\x1b[1m Rummikub in Go\x1b[0m — %s's turn — pool: %d tiles",
+	"sin abrir":                    "not opened",
+	"ha abierto":                   "opened",
+	"%-16s %2d fichas  (%s)":       "%-16s %2d tiles  (%s)",
+	"%+d puntos":                   "%+d points",
+	"--- Jugadores ---":            "--- Players ---",
+	"Quedan %d fichas en el pozo.": "%d tiles left in the pool.",
+	"\x1b[1m Mesa\x1b[0m":          "\x1b[1m Table\x1b[0m",
+	"sin abrir: tu primera jugada debe sumar 30 puntos": "not opened: your first meld must be worth 30 points",
+	"ya has abierto": "already opened",
+	"\x1b[1m Tu mano\x1b[0m (%d fichas, %s, orden %s)": "\x1b[1m Your hand\x1b[0m (%d tiles, %s, order %s)",
+	"Has abandonado la partida.":                       "You left the game.",
+	"Movimiento inválido: %v. Tu turno ha terminado.":  "Invalid move: %v. Your turn is over.",
+}
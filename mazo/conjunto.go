@@ -0,0 +1,143 @@
+package mazo
+
+import "fmt"
+
+// --- CONJUNTO DE FICHAS (MULTICONJUNTO) ---
+
+// ConjuntoFichas cuenta cuántas fichas hay de cada color y número, más los comodines.
+// Es un valor pequeño y sin punteros: copiarlo es barato y compararlo con == es válido.
+// A diferencia de un []Pieza, no tiene orden y las fichas duplicadas son intercambiables.
+type ConjuntoFichas struct {
+	cuentas   [4][14]uint8 // [color][numero]; el índice 0 de número no se usa.
+	comodines uint8
+	total     uint16
+}
+
+// NuevoConjunto construye un conjunto a partir de una lista de fichas.
+func NuevoConjunto(fichas []Pieza) ConjuntoFichas {
+	var c ConjuntoFichas
+	for _, f := range fichas {
+		c.Agregar(f)
+	}
+	return c
+}
+
+// cuenta devuelve un puntero al contador de la ficha, o nil si la ficha no existe en el juego.
+func (c *ConjuntoFichas) cuenta(p Pieza) *uint8 {
+	if p.Numero == 0 {
+		return &c.comodines
+	}
+	if p.Color < Rojo || p.Color > Negro || p.Numero < 1 || p.Numero > 13 {
+		return nil
+	}
+	return &c.cuentas[p.Color][p.Numero]
+}
+
+// Agregar añade una ficha al conjunto. Entra en pánico si la ficha no existe en el juego.
+func (c *ConjuntoFichas) Agregar(p Pieza) {
+	n := c.cuenta(p)
+	if n == nil {
+		panic(fmt.Sprintf("ficha inválida: color %d, número %d", p.Color, p.Numero))
+	}
+	*n++
+	c.total++
+}
+
+// Quitar saca una copia de la ficha. Devuelve false si no había ninguna.
+func (c *ConjuntoFichas) Quitar(p Pieza) bool {
+	n := c.cuenta(p)
+	if n == nil || *n == 0 {
+		return false
+	}
+	*n--
+	c.total--
+	return true
+}
+
+// Cuenta devuelve cuántas copias de la ficha hay en el conjunto.
+func (c ConjuntoFichas) Cuenta(p Pieza) int {
+	n := c.cuenta(p)
+	if n == nil {
+		return 0
+	}
+	return int(*n)
+}
+
+// Contiene indica si hay al menos una copia de la ficha.
+func (c ConjuntoFichas) Contiene(p Pieza) bool {
+	return c.Cuenta(p) > 0
+}
+
+// Len devuelve el número total de fichas, contando duplicados.
+func (c ConjuntoFichas) Len() int {
+	return int(c.total)
+}
+
+// Comodines devuelve cuántos comodines hay en el conjunto.
+func (c ConjuntoFichas) Comodines() int {
+	return int(c.comodines)
+}
+
+// ContieneTodas indica si otro es un subconjunto de c (contando duplicados).
+func (c ConjuntoFichas) ContieneTodas(otro ConjuntoFichas) bool {
+	if otro.comodines > c.comodines {
+		return false
+	}
+	for color := range c.cuentas {
+		for numero := range c.cuentas[color] {
+			if otro.cuentas[color][numero] > c.cuentas[color][numero] {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Diferencia devuelve las fichas de c que no están en otro (contando duplicados).
+func (c ConjuntoFichas) Diferencia(otro ConjuntoFichas) ConjuntoFichas {
+	var d ConjuntoFichas
+	resta := func(a, b uint8) uint8 {
+		if a > b {
+			return a - b
+		}
+		return 0
+	}
+	d.comodines = resta(c.comodines, otro.comodines)
+	d.total = uint16(d.comodines)
+	for color := range c.cuentas {
+		for numero := range c.cuentas[color] {
+			d.cuentas[color][numero] = resta(c.cuentas[color][numero], otro.cuentas[color][numero])
+			d.total += uint16(d.cuentas[color][numero])
+		}
+	}
+	return d
+}
+
+// Union devuelve un conjunto con las fichas de c y las de otro.
+func (c ConjuntoFichas) Union(otro ConjuntoFichas) ConjuntoFichas {
+	u := c
+	u.comodines += otro.comodines
+	u.total += otro.total
+	for color := range c.cuentas {
+		for numero := range c.cuentas[color] {
+			u.cuentas[color][numero] += otro.cuentas[color][numero]
+		}
+	}
+	return u
+}
+
+// Fichas devuelve las fichas del conjunto por color y número, con los comodines al final.
+func (c ConjuntoFichas) Fichas() []Pieza {
+	fichas := make([]Pieza, 0, c.total)
+	for color := range c.cuentas {
+		for numero := 1; numero <= 13; numero++ {
+			for k := uint8(0); k < c.cuentas[color][numero]; k++ {
+				fichas = append(fichas, Pieza{Color: color, Numero: numero})
+			}
+		}
+	}
+	for k := uint8(0); k < c.comodines; k++ {
+		fichas = append(fichas, Pieza{Color: -1, Numero: 0})
+	}
+	return fichas
+}
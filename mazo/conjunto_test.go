@@ -0,0 +1,107 @@
+package mazo
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestConjuntoFichas(t *testing.T) {
+	comodin := Pieza{Color: -1, Numero: 0}
+	rojo5 := Pieza{Color: Rojo, Numero: 5}
+	azul7 := Pieza{Color: Azul, Numero: 7}
+	casosDePrueba := []struct {
+		nombre    string
+		fichas    []Pieza
+		quitar    []Pieza
+		esperadas []Pieza
+		quitadas  int
+	}{
+		{
+			nombre:    "Los duplicados se cuentan por separado",
+			fichas:    []Pieza{rojo5, azul7, rojo5},
+			quitar:    []Pieza{rojo5},
+			esperadas: []Pieza{rojo5, azul7},
+			quitadas:  1,
+		},
+		{
+			nombre:    "Quitar una ficha que no está no hace nada",
+			fichas:    []Pieza{azul7, comodin},
+			quitar:    []Pieza{rojo5},
+			esperadas: []Pieza{azul7, comodin},
+			quitadas:  0,
+		},
+		{
+			nombre:    "Los comodines van al final",
+			fichas:    []Pieza{comodin, azul7, comodin, rojo5},
+			quitar:    []Pieza{comodin},
+			esperadas: []Pieza{rojo5, azul7, comodin},
+			quitadas:  1,
+		},
+	}
+
+	for _, tc := range casosDePrueba {
+		t.Run(tc.nombre, func(t *testing.T) {
+			c := NuevoConjunto(tc.fichas)
+			quitadas := 0
+			for _, p := range tc.quitar {
+				if c.Quitar(p) {
+					quitadas++
+				}
+			}
+			if quitadas != tc.quitadas {
+				t.Errorf("Se esperaba quitar %d fichas, pero se quitaron %d", tc.quitadas, quitadas)
+			}
+			if c.Len() != len(tc.esperadas) {
+				t.Errorf("Se esperaban %d fichas, pero hay %d", len(tc.esperadas), c.Len())
+			}
+			if got := c.Fichas(); !reflect.DeepEqual(got, tc.esperadas) {
+				t.Errorf("Se esperaba %v, pero fue %v", tc.esperadas, got)
+			}
+		})
+	}
+}
+
+func TestConjuntoFichasOperaciones(t *testing.T) {
+	mazo := NuevoConjunto(Completo())
+	if mazo.Len() != 106 || mazo.Comodines() != 2 || mazo.Cuenta(Pieza{Color: Negro, Numero: 13}) != 2 {
+		t.Fatalf("Se esperaba un juego completo de 106 fichas, pero fue %v", mazo.Fichas())
+	}
+	mano := NuevoConjunto([]Pieza{{Color: Rojo, Numero: 1}, {Color: Rojo, Numero: 1}, {Color: -1, Numero: 0}})
+	if !mazo.ContieneTodas(mano) {
+		t.Errorf("Se esperaba que el mazo contuviera la mano")
+	}
+	resto := mazo.Diferencia(mano)
+	if resto.Len() != 103 || resto.Contiene(Pieza{Color: Rojo, Numero: 1}) {
+		t.Errorf("Se esperaban 103 fichas sin el rojo 1, pero fue %d", resto.Len())
+	}
+	if resto.ContieneTodas(mano) {
+		t.Errorf("No se esperaba que el resto contuviera la mano")
+	}
+	if resto.Union(mano) != mazo {
+		t.Errorf("Se esperaba recuperar el mazo completo al unir el resto y la mano")
+	}
+}
+
+func TestIdentidadFichas(t *testing.T) {
+	mazo := Completo()
+	vistos := make(map[int]bool)
+	for _, f := range mazo {
+		if f.ID == 0 || vistos[f.ID] {
+			t.Fatalf("Se esperaba un ID único y distinto de 0, pero %s tiene %d", f, f.ID)
+		}
+		vistos[f.ID] = true
+	}
+
+	// Las dos copias del rojo 1 valen lo mismo pero no son la misma ficha.
+	copia1, copia2 := mazo[0], mazo[52]
+	if !copia1.MismoValor(copia2) || copia1 == copia2 {
+		t.Errorf("Se esperaba que %v y %v tuvieran el mismo valor y distinto ID", copia1, copia2)
+	}
+
+	var leida Pieza
+	datos, _ := json.Marshal(copia2)
+	if err := json.Unmarshal(datos, &leida); err != nil || leida != copia2 {
+		t.Errorf("Se esperaba conservar la ficha %+v al serializarla, pero fue %+v (%v)", copia2, leida, err)
+	}
+}
@@ -0,0 +1,125 @@
+package mazo
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"com.github/hapkiduki/rummikub/idioma"
+)
+
+// --- NOTACIÓN DE FICHAS ---
+//
+// Cada ficha se escribe con la inicial de su color en inglés seguida del número:
+// R7 (rojo), B12 (azul), Y1 (amarillo), K13 (negro) y J para el comodín. Es la
+// forma corta que usan la entrada del jugador humano, los tests y los registros.
+
+// letrasColor son las iniciales de cada color, en el orden de las constantes.
+var letrasColor = []byte{'R', 'B', 'Y', 'K'}
+
+// Notacion devuelve la ficha en notación corta, por ejemplo "R7" o "J".
+func (p Pieza) Notacion() string {
+	if p.Numero == 0 {
+		return "J"
+	}
+	if p.Color < Rojo || p.Color > Negro {
+		return fmt.Sprintf("?%d", p.Numero)
+	}
+	return fmt.Sprintf("%c%d", letrasColor[p.Color], p.Numero)
+}
+
+// NotacionFichas escribe varias fichas en notación corta separadas por espacios.
+func NotacionFichas(fichas []Pieza) string {
+	partes := make([]string, len(fichas))
+	for i, ficha := range fichas {
+		partes[i] = ficha.Notacion()
+	}
+	return strings.Join(partes, " ")
+}
+
+// NotacionConID añade a la notación corta el ID de la ficha, por ejemplo "R7#12".
+// Las partidas guardadas la usan para no confundir las dos copias de cada ficha.
+func (p Pieza) NotacionConID() string {
+	return fmt.Sprintf("%s#%d", p.Notacion(), p.ID)
+}
+
+// NotacionFichasConID escribe varias fichas con NotacionConID separadas por espacios.
+func NotacionFichasConID(fichas []Pieza) string {
+	partes := make([]string, len(fichas))
+	for i, ficha := range fichas {
+		partes[i] = ficha.NotacionConID()
+	}
+	return strings.Join(partes, " ")
+}
+
+// ParsearFicha lee una ficha en notación corta. No distingue mayúsculas de
+// minúsculas. La ficha devuelta no tiene ID: se compara con MismoValor.
+func ParsearFicha(texto string) (Pieza, error) {
+	texto = strings.ToUpper(strings.TrimSpace(texto))
+	if texto == "" {
+		return Pieza{}, errors.New(idioma.T("falta la ficha (usa, por ejemplo, R7 o J)"))
+	}
+	if texto == "J" {
+		return Pieza{Color: -1, Numero: 0}, nil
+	}
+	color := strings.IndexByte(string(letrasColor), texto[0])
+	if color < 0 {
+		return Pieza{}, fmt.Errorf(idioma.T("'%s': color desconocido '%c' (usa R, B, Y o K, o J para el comodín)"), texto, []rune(texto)[0])
+	}
+	numeroStr := texto[1:]
+	if numeroStr == "" {
+		return Pieza{}, fmt.Errorf(idioma.T("'%s': falta el número después del color"), texto)
+	}
+	numero, err := strconv.Atoi(numeroStr)
+	if err != nil {
+		return Pieza{}, fmt.Errorf(idioma.T("'%s': '%s' no es un número"), texto, numeroStr)
+	}
+	if numero < 1 || numero > 13 {
+		return Pieza{}, fmt.Errorf(idioma.T("'%s': el número debe estar entre 1 y 13"), texto)
+	}
+	return Pieza{Color: color, Numero: numero}, nil
+}
+
+// ParsearFichas lee una lista de fichas separadas por espacios o comas, como
+// "R7 R8 R9" o "B5, J, B7".
+func ParsearFichas(texto string) ([]Pieza, error) {
+	partes := SepararEntrada(texto)
+	fichas := make([]Pieza, 0, len(partes))
+	for _, parte := range partes {
+		ficha, err := ParsearFicha(parte)
+		if err != nil {
+			return nil, err
+		}
+		fichas = append(fichas, ficha)
+	}
+	return fichas, nil
+}
+
+// ParsearFichasConID lee una lista escrita con NotacionFichasConID. Cada ficha debe
+// llevar su ID; que el ID corresponda a la ficha lo comprueba quien la use.
+func ParsearFichasConID(texto string) ([]Pieza, error) {
+	partes := SepararEntrada(texto)
+	fichas := make([]Pieza, 0, len(partes))
+	for _, parte := range partes {
+		notacion, id, ok := strings.Cut(parte, "#")
+		if !ok {
+			return nil, fmt.Errorf(idioma.T("'%s': falta el ID de la ficha (por ejemplo, R7#12)"), parte)
+		}
+		ficha, err := ParsearFicha(notacion)
+		if err != nil {
+			return nil, err
+		}
+		if ficha.ID, err = strconv.Atoi(id); err != nil || ficha.ID < 1 {
+			return nil, fmt.Errorf(idioma.T("'%s': '%s' no es un ID de ficha"), parte, id)
+		}
+		fichas = append(fichas, ficha)
+	}
+	return fichas, nil
+}
+
+// SepararEntrada divide lo que escribe el jugador en palabras, por espacios o comas.
+func SepararEntrada(texto string) []string {
+	return strings.FieldsFunc(texto, func(r rune) bool { return r == ',' || unicode.IsSpace(r) })
+}
@@ -0,0 +1,82 @@
+package mazo
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestNotacionFichas(t *testing.T) {
+	casosDePrueba := []struct {
+		texto    string
+		esperado Pieza
+		error    string // Un trozo del mensaje de error, o vacío si es válida.
+	}{
+		{texto: "R7", esperado: Pieza{Color: Rojo, Numero: 7}},
+		{texto: "b12", esperado: Pieza{Color: Azul, Numero: 12}},
+		{texto: "Y1", esperado: Pieza{Color: Amarillo, Numero: 1}},
+		{texto: "K13", esperado: Pieza{Color: Negro, Numero: 13}},
+		{texto: "j", esperado: Pieza{Color: -1, Numero: 0}},
+		{texto: "X7", error: "color desconocido 'X'"},
+		{texto: "R", error: "falta el número"},
+		{texto: "R1x", error: "'1X' no es un número"},
+		{texto: "B14", error: "entre 1 y 13"},
+		{texto: "", error: "falta la ficha"},
+	}
+	for _, tc := range casosDePrueba {
+		ficha, err := ParsearFicha(tc.texto)
+		if tc.error != "" {
+			if err == nil || !strings.Contains(err.Error(), tc.error) {
+				t.Errorf("Se esperaba un error con %q para %q, pero fue %v", tc.error, tc.texto, err)
+			}
+			continue
+		}
+		if err != nil || ficha != tc.esperado {
+			t.Errorf("Se esperaba %v para %q, pero fue %v (%v)", tc.esperado, tc.texto, ficha, err)
+		}
+		if notacion := ficha.Notacion(); notacion != strings.ToUpper(tc.texto) {
+			t.Errorf("Se esperaba la notación %q, pero fue %q", strings.ToUpper(tc.texto), notacion)
+		}
+	}
+	if texto := NotacionFichas(fichasDe(t, "R7, r8 J")); texto != "R7 R8 J" {
+		t.Errorf("Se esperaba \"R7 R8 J\", pero fue %q", texto)
+	}
+}
+
+func TestNotacionConID(t *testing.T) {
+	completo := Completo()
+	fichas := []Pieza{completo[6], completo[58], completo[105]}
+	texto := NotacionFichasConID(fichas)
+	if texto != "R7#7 R7#59 J#106" {
+		t.Errorf("Se esperaba \"R7#7 R7#59 J#106\", pero fue %q", texto)
+	}
+	leidas, err := ParsearFichasConID(texto)
+	if err != nil || !reflect.DeepEqual(leidas, fichas) {
+		t.Errorf("Se esperaba recuperar %v, pero fue %v (%v)", fichas, leidas, err)
+	}
+
+	casosDePrueba := []struct {
+		texto string
+		error string
+	}{
+		{texto: "R7", error: "falta el ID"},
+		{texto: "R7#", error: "no es un ID"},
+		{texto: "R7#0", error: "no es un ID"},
+		{texto: "X7#7", error: "color desconocido"},
+	}
+	for _, tc := range casosDePrueba {
+		if _, err := ParsearFichasConID(tc.texto); err == nil || !strings.Contains(err.Error(), tc.error) {
+			t.Errorf("Se esperaba un error con %q para %q, pero fue %v", tc.error, tc.texto, err)
+		}
+	}
+}
+
+// fichasDe lee fichas en notación corta para preparar las pruebas.
+func fichasDe(t testing.TB, notacion string) []Pieza {
+	t.Helper()
+	fichas, err := ParsearFichas(notacion)
+	if err != nil {
+		t.Fatalf("notación inválida en la prueba: %v", err)
+	}
+	return fichas
+}
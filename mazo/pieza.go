@@ -0,0 +1,65 @@
+// Package mazo define las fichas del Rummikub, el juego completo de 106 fichas, el
+// pozo del que se roba y la notación corta para escribirlas (R7, B12, J...).
+package mazo
+
+import "com.github/hapkiduki/rummikub/idioma"
+
+// Colores de las fichas. El comodín tiene el color -1 y el número 0.
+const (
+	Rojo = iota
+	Azul
+	Amarillo
+	Negro
+)
+
+// Pieza es una ficha del juego. ID distingue las dos copias de cada ficha (y los dos
+// comodines): Completo numera las fichas del 1 al 106 y el número las acompaña al
+// repartir, en la mano, en la mesa y al serializarlas. Una Pieza creada a mano tiene
+// ID 0. Las reglas comparan por valor con MismoValor; == compara también el ID.
+type Pieza struct {
+	ID     int `json:"id"`
+	Color  int `json:"color"`
+	Numero int `json:"numero"`
+}
+
+// MismoValor indica si dos fichas tienen el mismo color y número, sea cual sea su ID.
+func (p Pieza) MismoValor(otra Pieza) bool {
+	return p.Color == otra.Color && p.Numero == otra.Numero
+}
+
+// String describe la ficha en el idioma activo, con el icono de su color.
+func (p Pieza) String() string {
+	if p.Numero == 0 {
+		return idioma.T("Comodín 🃏")
+	}
+	iconos := []string{"🔴", "🔵", "🟡", "⚫"}
+	colorStr := ""
+	switch p.Color {
+	case Rojo:
+		colorStr = idioma.T("Rojo")
+	case Azul:
+		colorStr = idioma.T("Azul")
+	case Amarillo:
+		colorStr = idioma.T("Amarillo")
+	case Negro:
+		colorStr = idioma.T("Negro")
+	}
+	return idioma.Tf("%s Ficha(%s, %d)", iconos[p.Color], colorStr, p.Numero)
+}
+
+// Completo devuelve las 106 fichas del juego sin barajar. Cada ficha recibe un ID
+// distinto, que es su posición en el mazo sin barajar más uno.
+func Completo() []Pieza {
+	mazo := make([]Pieza, 0, 106) // Pre-allocating capacity
+	colores := []int{Rojo, Azul, Amarillo, Negro}
+	for i := 0; i < 2; i++ {
+		for _, color := range colores {
+			for numero := 1; numero <= 13; numero++ {
+				mazo = append(mazo, Pieza{ID: len(mazo) + 1, Color: color, Numero: numero})
+			}
+		}
+	}
+	mazo = append(mazo, Pieza{ID: len(mazo) + 1, Color: -1, Numero: 0})
+	mazo = append(mazo, Pieza{ID: len(mazo) + 1, Color: -1, Numero: 0})
+	return mazo
+}
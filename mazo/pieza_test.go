@@ -0,0 +1,26 @@
+package mazo
+
+import (
+	"testing"
+
+	"com.github/hapkiduki/rummikub/idioma"
+)
+
+func TestPiezaString(t *testing.T) {
+	defer idioma.Usar(idioma.Actual())
+	casosDePrueba := []struct {
+		idioma   idioma.Idioma
+		ficha    Pieza
+		esperado string
+	}{
+		{idioma.Espanol, Pieza{Color: Azul, Numero: 7}, "🔵 Ficha(Azul, 7)"},
+		{idioma.Ingles, Pieza{Color: Azul, Numero: 7}, "🔵 Tile(Blue, 7)"},
+		{idioma.Espanol, Pieza{Color: -1, Numero: 0}, "Comodín 🃏"},
+	}
+	for _, tc := range casosDePrueba {
+		idioma.Usar(tc.idioma)
+		if resultado := tc.ficha.String(); resultado != tc.esperado {
+			t.Errorf("Se esperaba %q en %s, pero se obtuvo %q", tc.esperado, tc.idioma, resultado)
+		}
+	}
+}
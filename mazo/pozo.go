@@ -0,0 +1,75 @@
+package mazo
+
+// --- POZO DE FICHAS ---
+
+// MotivoRobo explica por qué un jugador robó una ficha.
+type MotivoRobo string
+
+const (
+	RoboObligatorio  MotivoRobo = "obligatorio"  // El jugador no bajó ninguna ficha en su turno.
+	RoboPenalizacion MotivoRobo = "penalizacion" // Castigo por un movimiento inválido (regla de la casa).
+)
+
+// Robo es una entrada del historial del pozo.
+type Robo struct {
+	Turno   int        `json:"turno"`
+	Jugador string     `json:"jugador"`
+	Ficha   Pieza      `json:"ficha"`
+	Motivo  MotivoRobo `json:"motivo"`
+}
+
+// Pozo guarda las fichas boca abajo que quedan por robar, ya barajadas, y recuerda
+// quién robó cada una. Solo se roba por arriba: el orden no cambia salvo que se
+// devuelvan fichas con Devolver.
+type Pozo struct {
+	fichas    []Pieza
+	historial []Robo
+}
+
+// NuevoPozo crea un pozo con las fichas indicadas, en ese orden.
+func NuevoPozo(fichas []Pieza) *Pozo {
+	return &Pozo{fichas: append([]Pieza(nil), fichas...)}
+}
+
+// Robar saca la ficha de arriba y la apunta en el historial. Devuelve false si el
+// pozo está vacío.
+func (z *Pozo) Robar(jugador string, turno int, motivo MotivoRobo) (Pieza, bool) {
+	if len(z.fichas) == 0 {
+		return Pieza{}, false
+	}
+	ficha := z.fichas[0]
+	z.fichas = z.fichas[1:]
+	z.historial = append(z.historial, Robo{Turno: turno, Jugador: jugador, Ficha: ficha, Motivo: motivo})
+	return ficha, true
+}
+
+// Repartir saca hasta n fichas de arriba sin apuntarlas en el historial: repartir
+// no es robar.
+func (z *Pozo) Repartir(n int) []Pieza {
+	n = min(n, len(z.fichas))
+	fichas := append([]Pieza(nil), z.fichas[:n]...)
+	z.fichas = z.fichas[n:]
+	return fichas
+}
+
+// Devolver vuelve a poner las fichas en el pozo y lo baraja entero con barajar, que
+// funciona como rand.Shuffle.
+func (z *Pozo) Devolver(fichas []Pieza, barajar func(n int, swap func(i, j int))) {
+	z.fichas = append(append([]Pieza(nil), fichas...), z.fichas...)
+	barajar(len(z.fichas), func(i, j int) { z.fichas[i], z.fichas[j] = z.fichas[j], z.fichas[i] })
+}
+
+// Restantes devuelve cuántas fichas quedan por robar.
+func (z *Pozo) Restantes() int {
+	return len(z.fichas)
+}
+
+// Fichas devuelve una copia de las fichas que quedan, de arriba abajo.
+func (z *Pozo) Fichas() []Pieza {
+	return append([]Pieza(nil), z.fichas...)
+}
+
+// Historial devuelve una copia de todos los robos, del primero al último.
+func (z *Pozo) Historial() []Robo {
+	return append([]Robo(nil), z.historial...)
+}
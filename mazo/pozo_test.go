@@ -0,0 +1,42 @@
+package mazo
+
+import "testing"
+
+func TestPozo(t *testing.T) {
+	fichas := Completo()[:3]
+	pozo := NuevoPozo(fichas)
+	for i, esperada := range fichas {
+		ficha, ok := pozo.Robar("Ana", i, RoboObligatorio)
+		if !ok || ficha != esperada {
+			t.Fatalf("Se esperaba robar %v, pero fue %v (%v)", esperada, ficha, ok)
+		}
+	}
+	if _, ok := pozo.Robar("Ana", 3, RoboObligatorio); ok || pozo.Restantes() != 0 {
+		t.Errorf("Se esperaba el pozo vacío, pero quedan %d fichas", pozo.Restantes())
+	}
+	historial := pozo.Historial()
+	if len(historial) != 3 || historial[2].Ficha != fichas[2] || historial[2].Turno != 2 || historial[2].Jugador != "Ana" {
+		t.Errorf("Se esperaban 3 robos en el historial, pero fue %+v", historial)
+	}
+}
+
+func TestRepartirYDevolver(t *testing.T) {
+	fichas := Completo()[:5]
+	pozo := NuevoPozo(fichas)
+	repartidas := pozo.Repartir(2)
+	if len(repartidas) != 2 || repartidas[0] != fichas[0] || repartidas[1] != fichas[1] || len(pozo.Historial()) != 0 {
+		t.Fatalf("Se esperaban las 2 fichas de arriba sin historial, pero fueron %v", repartidas)
+	}
+	if resto := pozo.Repartir(10); len(resto) != 3 || pozo.Restantes() != 0 {
+		t.Errorf("Se esperaban las 3 fichas que quedaban, pero fueron %v", resto)
+	}
+	invertir := func(n int, swap func(i, j int)) {
+		for i := 0; i < n/2; i++ {
+			swap(i, n-1-i)
+		}
+	}
+	pozo.Devolver(repartidas, invertir)
+	if fichas := pozo.Fichas(); len(fichas) != 2 || fichas[0] != repartidas[1] {
+		t.Errorf("Se esperaba el pozo barajado con las fichas devueltas, pero es %v", fichas)
+	}
+}
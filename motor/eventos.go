@@ -0,0 +1,278 @@
+package motor
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"com.github/hapkiduki/rummikub/idioma"
+	"com.github/hapkiduki/rummikub/mazo"
+)
+
+// --- EVENTOS DE LA PARTIDA ---
+
+// Evento es algo que ha pasado en la partida. Los tipos de evento son los de este
+// archivo; los observadores los distinguen con un type switch.
+type Evento interface {
+	// Tipo es el nombre del evento en los registros, como "jugada_bajada".
+	Tipo() string
+}
+
+// PartidaIniciada se emite antes de repartir, con los jugadores en el orden de los
+// asientos.
+type PartidaIniciada struct {
+	Jugadores []string `json:"jugadores"`
+}
+
+// InicioSorteado se emite tras el sorteo del primer jugador (ReglasCasa.SortearInicio),
+// con todas las fichas sacadas en orden, desempates incluidos.
+type InicioSorteado struct {
+	Fichas  []FichaSorteo `json:"fichas"`
+	Jugador string        `json:"jugador"` // El que empieza.
+}
+
+// FichasRepartidas se emite al terminar el reparto. Manos está en el orden de los
+// asientos.
+type FichasRepartidas struct {
+	Manos        [][]mazo.Pieza `json:"manos"`
+	FichasEnPozo int            `json:"fichas_en_pozo"`
+}
+
+// TurnoIniciado se emite antes de que la estrategia del jugador juegue su turno.
+type TurnoIniciado struct {
+	Turno   int    `json:"turno"`
+	Jugador string `json:"jugador"`
+}
+
+// JugadorPensando se emite después de TurnoIniciado cuando la estrategia del jugador
+// es una EstrategiaPensativa que va a pararse a pensar.
+type JugadorPensando struct {
+	Turno   int    `json:"turno"`
+	Jugador string `json:"jugador"`
+}
+
+// JugadorAbrio se emite cuando un jugador hace su primera jugada, con lo que suman
+// las jugadas que ha bajado en ese turno.
+type JugadorAbrio struct {
+	Turno   int    `json:"turno"`
+	Jugador string `json:"jugador"`
+	Puntos  int    `json:"puntos"`
+}
+
+// JugadaBajada es una jugada nueva hecha solo con fichas de la mano. Jugada es su
+// posición en la mesa al acabar el turno.
+type JugadaBajada struct {
+	Turno   int          `json:"turno"`
+	Jugador string       `json:"jugador"`
+	Fichas  []mazo.Pieza `json:"fichas"`
+	Jugada  int          `json:"jugada"`
+}
+
+// FichaAnadida es una ficha de la mano que ha acabado en una jugada con fichas que ya
+// estaban en la mesa.
+type FichaAnadida struct {
+	Turno   int        `json:"turno"`
+	Jugador string     `json:"jugador"`
+	Ficha   mazo.Pieza `json:"ficha"`
+	Jugada  int        `json:"jugada"`
+}
+
+// FichasRobadas se emite cada vez que el motor hace robar a un jugador. Fichas puede
+// estar vacío si el pozo se ha acabado.
+type FichasRobadas struct {
+	Turno   int             `json:"turno"`
+	Jugador string          `json:"jugador"`
+	Fichas  []mazo.Pieza    `json:"fichas"`
+	Motivo  mazo.MotivoRobo `json:"motivo"`
+}
+
+// MesaInvalida se emite cuando el jugador deja la mesa mal y se deshace su turno.
+// Si Penalizada, a continuación llega el robo de penalización.
+type MesaInvalida struct {
+	Turno      int    `json:"turno"`
+	Jugador    string `json:"jugador"`
+	Motivo     string `json:"motivo"`
+	Penalizada bool   `json:"penalizada"`
+}
+
+// MovimientoRechazado se emite cuando se rechaza un movimiento de un bot externo, que
+// puede volver a intentarlo o acabar su turno.
+type MovimientoRechazado struct {
+	Turno   int    `json:"turno"`
+	Jugador string `json:"jugador"`
+	Motivo  string `json:"motivo"`
+}
+
+// ProgramaDetenido se emite cuando el programa de un bot externo falla y deja de jugar,
+// con el fallo en Motivo, y en cada turno que pierde después, con Motivo vacío.
+type ProgramaDetenido struct {
+	Turno   int    `json:"turno"`
+	Jugador string `json:"jugador"`
+	Motivo  string `json:"motivo,omitempty"`
+}
+
+// JugadorSustituido se emite cuando un bot ocupa el asiento de un jugador remoto que
+// se ha desconectado o, si TiempoAgotado, que no ha respondido a tiempo en su turno.
+type JugadorSustituido struct {
+	Turno         int    `json:"turno"`
+	Jugador       string `json:"jugador"`
+	TiempoAgotado bool   `json:"tiempo_agotado"`
+}
+
+// PartidaTerminada se emite al acabar la partida, con lo que le queda a cada jugador
+// en el orden de los asientos.
+type PartidaTerminada struct {
+	Turno       int                `json:"turno"`
+	Ganador     string             `json:"ganador"`
+	MazoAgotado bool               `json:"mazo_agotado"`
+	Jugadores   []ResultadoJugador `json:"jugadores"`
+}
+
+// ResultadoJugador es cómo ha acabado un jugador la partida.
+type ResultadoJugador struct {
+	Nombre       string `json:"nombre"`
+	Puntos       int    `json:"puntos"` // Puntos de las fichas que le quedan en la mano.
+	PistasUsadas int    `json:"pistas_usadas"`
+}
+
+func (PartidaIniciada) Tipo() string     { return "partida_iniciada" }
+func (InicioSorteado) Tipo() string      { return "inicio_sorteado" }
+func (FichasRepartidas) Tipo() string    { return "fichas_repartidas" }
+func (TurnoIniciado) Tipo() string       { return "turno_iniciado" }
+func (JugadorPensando) Tipo() string     { return "jugador_pensando" }
+func (JugadorAbrio) Tipo() string        { return "jugador_abrio" }
+func (JugadaBajada) Tipo() string        { return "jugada_bajada" }
+func (FichaAnadida) Tipo() string        { return "ficha_anadida" }
+func (FichasRobadas) Tipo() string       { return "fichas_robadas" }
+func (MesaInvalida) Tipo() string        { return "mesa_invalida" }
+func (MovimientoRechazado) Tipo() string { return "movimiento_rechazado" }
+func (ProgramaDetenido) Tipo() string    { return "programa_detenido" }
+func (JugadorSustituido) Tipo() string   { return "jugador_sustituido" }
+func (PartidaTerminada) Tipo() string    { return "partida_terminada" }
+
+// --- OBSERVADORES ---
+
+// Observador recibe los eventos de una partida en el orden en que ocurren, desde la
+// goroutine que juega la partida. La partida ya refleja el evento; el observador no
+// debe modificarla.
+type Observador interface {
+	Notificar(p *Partida, e Evento)
+}
+
+// ObservadorFunc permite usar una función como Observador.
+type ObservadorFunc func(p *Partida, e Evento)
+
+// Notificar llama a f.
+func (f ObservadorFunc) Notificar(p *Partida, e Evento) {
+	f(p, e)
+}
+
+// Observar añade un observador a la partida. Los eventos del reparto solo llegan a
+// los que se pasan en OpcionesPartida.Observadores.
+func (p *Partida) Observar(o Observador) {
+	p.observadores = append(p.observadores, o)
+}
+
+// emitir avisa del evento a todos los observadores.
+func (p *Partida) emitir(e Evento) {
+	for _, o := range p.observadores {
+		o.Notificar(p, e)
+	}
+}
+
+// Emitir avisa a los observadores de un evento que no genera el propio motor, como los
+// fallos de un bot externo. El evento debe ser uno de los tipos de este archivo.
+func (p *Partida) Emitir(e Evento) {
+	p.emitir(e)
+}
+
+// --- NARRACIÓN POR CONSOLA ---
+
+// Narrador cuenta la partida en texto. NuevaPartida añade uno que escribe en la salida
+// estándar salvo que la partida sea silenciosa.
+type Narrador struct {
+	Salida io.Writer // nil escribe en la salida estándar.
+}
+
+// Notificar escribe una o varias líneas para cada evento.
+func (n Narrador) Notificar(_ *Partida, e Evento) {
+	switch e := e.(type) {
+	case PartidaIniciada:
+		n.anunciar("Repartiendo fichas...\n")
+	case InicioSorteado:
+		for _, f := range e.Fichas {
+			n.anunciar("%s saca %s.\n", f.Jugador, f.Ficha)
+		}
+		n.anunciar("Empieza %s.\n", e.Jugador)
+	case FichasRepartidas:
+		n.anunciar("¡Todas las fichas han sido repartidas!\n")
+	case TurnoIniciado:
+		n.anunciar("\n--- Turno de %s ---\n", e.Jugador)
+	case JugadorPensando:
+		n.anunciar("%s está pensando...\n", e.Jugador)
+	case JugadorAbrio:
+		n.anunciar("%s baja su primera jugada con %d puntos.\n", e.Jugador, e.Puntos)
+	case JugadaBajada:
+		n.anunciar("%s juega: %v\n", e.Jugador, e.Fichas)
+	case FichaAnadida:
+		n.anunciar("%s añade un(a) %s a la jugada %d.\n", e.Jugador, e.Ficha, e.Jugada)
+	case MesaInvalida:
+		if e.Penalizada {
+			n.anunciar("%s deja la mesa inválida (%v): recupera sus fichas.\n", e.Jugador, e.Motivo)
+		} else {
+			n.anunciar("%s deja la mesa inválida (%v): se deshace su turno.\n", e.Jugador, e.Motivo)
+		}
+	case MovimientoRechazado:
+		n.anunciar("Movimiento inválido de %s: %v\n", e.Jugador, e.Motivo)
+	case ProgramaDetenido:
+		if e.Motivo == "" {
+			n.anunciar("%s no puede jugar.\n", e.Jugador)
+		} else {
+			n.anunciar("El programa de %s ha fallado (%v) y se ha detenido.\n", e.Jugador, e.Motivo)
+		}
+	case JugadorSustituido:
+		if e.TiempoAgotado {
+			n.anunciar("A %s se le ha acabado el tiempo del turno. Un bot ocupa su asiento.\n", e.Jugador)
+		} else {
+			n.anunciar("%s se ha desconectado. Un bot ocupa su asiento.\n", e.Jugador)
+		}
+	case FichasRobadas:
+		switch {
+		case e.Motivo == mazo.RoboPenalizacion:
+			n.anunciar("%s roba %d ficha(s) de penalización.\n", e.Jugador, len(e.Fichas))
+		case len(e.Fichas) == 0:
+			n.anunciar("%s no puede robar: no quedan fichas en el pozo.\n", e.Jugador)
+		case len(e.Fichas) == 1:
+			n.anunciar("%s roba una ficha.\n", e.Jugador)
+		default:
+			n.anunciar("%s roba %d fichas.\n", e.Jugador, len(e.Fichas))
+		}
+	case PartidaTerminada:
+		if !e.MazoAgotado {
+			n.anunciar("\n¡Felicidades, %s! ¡Has ganado la partida!\n", e.Ganador)
+		} else {
+			n.anunciar("\n¡Se acabaron todas las fichas del mazo!\n")
+			puntosGanador := 0
+			for _, j := range e.Jugadores {
+				n.anunciar("%s tiene %d puntos en su mano.\n", j.Nombre, j.Puntos)
+				if j.Nombre == e.Ganador {
+					puntosGanador = j.Puntos
+				}
+			}
+			n.anunciar("\n¡Felicidades, %s! ¡Has ganado la partida con %d puntos!\n", e.Ganador, puntosGanador)
+		}
+		for _, j := range e.Jugadores {
+			if j.PistasUsadas > 0 {
+				n.anunciar("%s ha usado %d pista(s).\n", j.Nombre, j.PistasUsadas)
+			}
+		}
+	}
+}
+
+func (n Narrador) anunciar(format string, args ...any) {
+	salida := n.Salida
+	if salida == nil {
+		salida = os.Stdout
+	}
+	fmt.Fprintf(salida, idioma.T(format), args...)
+}
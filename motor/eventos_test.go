@@ -0,0 +1,161 @@
+package motor
+
+import (
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+
+	"com.github/hapkiduki/rummikub/mazo"
+)
+
+// tiposDeEvento resume una lista de eventos por su tipo, para compararla fácilmente.
+func tiposDeEvento(eventos []Evento) []string {
+	tipos := make([]string, len(eventos))
+	for i, e := range eventos {
+		tipos[i] = e.Tipo()
+	}
+	return tipos
+}
+
+func TestEventosDelTurno(t *testing.T) {
+	casosDePrueba := []struct {
+		nombre    string
+		abierto   bool
+		mesa      string // Jugada que hay en la mesa al empezar, si hay alguna.
+		mano      string // Fichas que recibe Ana además de las repartidas.
+		penalizar bool
+		juega     estrategiaFunc
+		esperados []string
+	}{
+		{
+			nombre: "Abrir con una escalera",
+			mano:   "R10 R11 R12",
+			juega: func(j *Jugador, mesa [][]mazo.Pieza) [][]mazo.Pieza {
+				mesa = append(mesa, j.Mano[len(j.Mano)-3:])
+				j.Mano = j.Mano[:len(j.Mano)-3]
+				j.HaHechoPrimeraJugada = true
+				return mesa
+			},
+			esperados: []string{"turno_iniciado", "jugador_abrio", "jugada_bajada"},
+		},
+		{
+			nombre:  "Añadir una ficha a la mesa",
+			abierto: true,
+			mesa:    "B1 B2 B3",
+			mano:    "B4",
+			juega: func(j *Jugador, mesa [][]mazo.Pieza) [][]mazo.Pieza {
+				mesa[0] = append(mesa[0], j.Mano[len(j.Mano)-1])
+				j.Mano = j.Mano[:len(j.Mano)-1]
+				return mesa
+			},
+			esperados: []string{"turno_iniciado", "ficha_anadida"},
+		},
+		{
+			nombre:    "No bajar nada",
+			juega:     func(j *Jugador, mesa [][]mazo.Pieza) [][]mazo.Pieza { return mesa },
+			esperados: []string{"turno_iniciado", "fichas_robadas"},
+		},
+		{
+			nombre:    "Dejar la mesa inválida con penalización",
+			abierto:   true,
+			penalizar: true,
+			juega: func(j *Jugador, mesa [][]mazo.Pieza) [][]mazo.Pieza {
+				mesa = append(mesa, append([]mazo.Pieza(nil), j.Mano[:2]...))
+				j.Mano = j.Mano[2:]
+				return mesa
+			},
+			esperados: []string{"turno_iniciado", "mesa_invalida", "fichas_robadas"},
+		},
+	}
+
+	for _, tc := range casosDePrueba {
+		t.Run(tc.nombre, func(t *testing.T) {
+			ana := &Jugador{Nombre: "Ana", Estrategia: tc.juega, HaHechoPrimeraJugada: tc.abierto}
+			jugadores := []*Jugador{ana, {Nombre: "Luis", Estrategia: estrategiaRobadora{}}}
+			partida := NuevaPartida(jugadores, OpcionesPartida{Rand: rand.New(rand.NewSource(1)), Silenciosa: true, Reglas: ReglasCasa{PenalizarMesaInvalida: tc.penalizar}})
+			if tc.mesa != "" {
+				partida.Mesa = append(partida.Mesa, sacarFichas(partida, fichasDe(t, tc.mesa)...))
+			}
+			if tc.mano != "" {
+				ana.Mano = append(ana.Mano, sacarFichas(partida, fichasDe(t, tc.mano)...)...)
+			}
+			var eventos []Evento
+			partida.Observar(ObservadorFunc(func(_ *Partida, e Evento) { eventos = append(eventos, e) }))
+			partida.JugarTurno()
+
+			if tipos := tiposDeEvento(eventos); !reflect.DeepEqual(tipos, tc.esperados) {
+				t.Errorf("Se esperaban los eventos %v, pero fueron %v", tc.esperados, tipos)
+			}
+		})
+	}
+}
+
+func TestEventosDeUnaPartida(t *testing.T) {
+	var eventos []Evento
+	var narracion strings.Builder
+	jugadores := []*Jugador{{Nombre: "Ana", Estrategia: estrategiaRobadora{}}, {Nombre: "Luis", Estrategia: estrategiaRobadora{}}}
+	partida := NuevaPartida(jugadores, OpcionesPartida{
+		Rand:         rand.New(rand.NewSource(1)),
+		Silenciosa:   true,
+		Observadores: []Observador{ObservadorFunc(func(_ *Partida, e Evento) { eventos = append(eventos, e) }), Narrador{Salida: &narracion}},
+	})
+	partida.Jugar()
+
+	tipos := tiposDeEvento(eventos)
+	if tipos[0] != "partida_iniciada" || tipos[1] != "fichas_repartidas" || tipos[len(tipos)-1] != "partida_terminada" {
+		t.Fatalf("Se esperaba empezar con el reparto y acabar con el final, pero los eventos fueron %v", tipos)
+	}
+	robadas := 0
+	for _, e := range eventos {
+		if robo, ok := e.(FichasRobadas); ok {
+			robadas += len(robo.Fichas)
+		}
+	}
+	if repartidas := eventos[1].(FichasRepartidas).FichasEnPozo; robadas != repartidas {
+		t.Errorf("Se esperaba robar las %d fichas del pozo, pero se robaron %d", repartidas, robadas)
+	}
+	if fin := eventos[len(eventos)-1].(PartidaTerminada); fin.Ganador != partida.Ganador.Nombre || !fin.MazoAgotado {
+		t.Errorf("Se esperaba que ganara %s con el mazo agotado, pero el evento es %+v", partida.Ganador.Nombre, fin)
+	}
+	if !strings.Contains(narracion.String(), "Luis roba una ficha.") {
+		t.Errorf("Se esperaba que el narrador contara los robos, pero escribió %q", narracion.String())
+	}
+}
+
+// estrategiaPensativa roba en cada turno y dice si se para a pensar.
+type estrategiaPensativa struct {
+	estrategiaRobadora
+	piensa bool
+}
+
+func (e estrategiaPensativa) Piensa() bool { return e.piensa }
+
+func TestJugadorPensando(t *testing.T) {
+	casosDePrueba := []struct {
+		nombre     string
+		estrategia Estrategia
+		esperados  []string
+	}{
+		{nombre: "Se para a pensar", estrategia: estrategiaPensativa{piensa: true}, esperados: []string{"turno_iniciado", "jugador_pensando", "fichas_robadas"}},
+		{nombre: "Sin pausas", estrategia: estrategiaPensativa{}, esperados: []string{"turno_iniciado", "fichas_robadas"}},
+		{nombre: "Estrategia sin Piensa", estrategia: estrategiaRobadora{}, esperados: []string{"turno_iniciado", "fichas_robadas"}},
+	}
+	for _, tc := range casosDePrueba {
+		t.Run(tc.nombre, func(t *testing.T) {
+			var eventos []Evento
+			var narracion strings.Builder
+			jugadores := []*Jugador{{Nombre: "Ana", Estrategia: tc.estrategia}, {Nombre: "Luis", Estrategia: estrategiaRobadora{}}}
+			partida := NuevaPartida(jugadores, OpcionesPartida{Rand: rand.New(rand.NewSource(1)), Silenciosa: true})
+			partida.Observar(ObservadorFunc(func(_ *Partida, e Evento) { eventos = append(eventos, e) }))
+			partida.Observar(Narrador{Salida: &narracion})
+			partida.JugarTurno()
+			if tipos := tiposDeEvento(eventos); !reflect.DeepEqual(tipos, tc.esperados) {
+				t.Errorf("Se esperaban los eventos %v, pero fueron %v", tc.esperados, tipos)
+			}
+			if piensa := strings.Contains(narracion.String(), "Ana está pensando..."); piensa != (len(tc.esperados) == 3) {
+				t.Errorf("El narrador no cuenta bien si Ana piensa: %q", narracion.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package motor
+
+import (
+	"com.github/hapkiduki/rummikub/mazo"
+	"com.github/hapkiduki/rummikub/reglas"
+)
+
+// Estrategia define el comportamiento de un jugador en su turno.
+// Cualquier tipo que implemente este método es una Estrategia válida.
+// La estrategia baja fichas de la mano a la mesa y devuelve la mesa; si no baja
+// ninguna, la Partida le hace robar del pozo al terminar el turno.
+type Estrategia interface {
+	JugarTurno(jugador *Jugador, mesa [][]mazo.Pieza) [][]mazo.Pieza
+}
+
+// EstrategiaPensativa es una estrategia que se para a pensar en su turno para que un
+// humano pueda seguir la partida. Si Piensa, la partida emite JugadorPensando antes
+// de dejarla jugar: quien cuenta la partida es el Narrador, no la estrategia.
+type EstrategiaPensativa interface {
+	Estrategia
+	Piensa() bool
+}
+
+// Jugador es un asiento de la partida: su mano, si ya ha abierto y la estrategia
+// que decide sus turnos.
+type Jugador struct {
+	Nombre               string
+	Mano                 []mazo.Pieza
+	HaHechoPrimeraJugada bool
+	Estrategia           Estrategia
+	OrdenMano            reglas.ModoOrden // Cómo se muestra la mano a un jugador humano.
+	PistasUsadas         int
+}
+
+// Reiniciar deja al jugador como antes del reparto para jugar otra partida en el mismo
+// asiento. Conserva su nombre, su estrategia y cómo ordena la mano.
+func (j *Jugador) Reiniciar() {
+	j.Mano = j.Mano[:0]
+	j.HaHechoPrimeraJugada = false
+	j.PistasUsadas = 0
+}
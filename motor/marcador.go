@@ -0,0 +1,58 @@
+package motor
+
+// --- PUNTUACIÓN DE UNA SERIE DE PARTIDAS ---
+
+// Marcador suma los puntos de una serie de partidas (rondas) entre los mismos
+// jugadores. Es un Observador: hay que añadirlo a cada partida de la serie y apunta
+// la ronda cuando esta termina.
+type Marcador struct {
+	Jugadores []string
+	Puntos    []int // Acumulados, en el orden de los asientos.
+	Rondas    int   // Partidas terminadas.
+}
+
+// NuevoMarcador crea un marcador a cero para los jugadores indicados.
+func NuevoMarcador(jugadores []*Jugador) *Marcador {
+	m := &Marcador{Jugadores: make([]string, len(jugadores)), Puntos: make([]int, len(jugadores))}
+	for i, j := range jugadores {
+		m.Jugadores[i] = j.Nombre
+	}
+	return m
+}
+
+// Notificar apunta los puntos de la ronda al terminar cada partida.
+func (m *Marcador) Notificar(_ *Partida, e Evento) {
+	fin, ok := e.(PartidaTerminada)
+	if !ok {
+		return
+	}
+	for i, puntos := range PuntosRonda(fin) {
+		m.Puntos[i] += puntos
+	}
+	m.Rondas++
+}
+
+// PuntosRonda calcula la puntuación oficial de una partida, por asiento: cada perdedor
+// resta lo que vale su mano (el comodín vale 30) y el ganador suma lo que restan los
+// demás. Si se acabó el pozo, a cada mano se le descuenta antes lo que vale la del
+// ganador.
+func PuntosRonda(fin PartidaTerminada) []int {
+	puntos := make([]int, len(fin.Jugadores))
+	ganador, base := -1, 0
+	for i, j := range fin.Jugadores {
+		if j.Nombre == fin.Ganador {
+			ganador, base = i, j.Puntos
+			break
+		}
+	}
+	if ganador < 0 {
+		return puntos
+	}
+	for i, j := range fin.Jugadores {
+		if i != ganador {
+			puntos[i] = base - j.Puntos
+			puntos[ganador] += j.Puntos - base
+		}
+	}
+	return puntos
+}
@@ -0,0 +1,57 @@
+package motor
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestPuntosRonda(t *testing.T) {
+	casosDePrueba := []struct {
+		nombre    string
+		fin       PartidaTerminada
+		esperados []int
+	}{
+		{
+			nombre:    "Gana quien se queda sin fichas",
+			fin:       PartidaTerminada{Ganador: "Luis", Jugadores: []ResultadoJugador{{Nombre: "Ana", Puntos: 12}, {Nombre: "Luis"}, {Nombre: "Eva", Puntos: 40}}},
+			esperados: []int{-12, 52, -40},
+		},
+		{
+			nombre:    "Se acaba el pozo",
+			fin:       PartidaTerminada{Ganador: "Ana", MazoAgotado: true, Jugadores: []ResultadoJugador{{Nombre: "Ana", Puntos: 5}, {Nombre: "Luis", Puntos: 20}}},
+			esperados: []int{15, -15},
+		},
+	}
+
+	for _, tc := range casosDePrueba {
+		t.Run(tc.nombre, func(t *testing.T) {
+			if puntos := PuntosRonda(tc.fin); !reflect.DeepEqual(puntos, tc.esperados) {
+				t.Errorf("Se esperaban los puntos %v, pero fueron %v", tc.esperados, puntos)
+			}
+		})
+	}
+}
+
+func TestMarcador(t *testing.T) {
+	jugadores := []*Jugador{{Nombre: "Ana", Estrategia: estrategiaRobadora{}}, {Nombre: "Luis", Estrategia: estrategiaRobadora{}}}
+	marcador := NuevoMarcador(jugadores)
+	for ronda := 0; ronda < 2; ronda++ {
+		for _, j := range jugadores {
+			j.Reiniciar()
+		}
+		partida := NuevaPartida(jugadores, OpcionesPartida{
+			Rand:         rand.New(rand.NewSource(int64(ronda))),
+			Silenciosa:   true,
+			Primero:      ronda,
+			Observadores: []Observador{marcador},
+		})
+		if partida.JugadorActual() != jugadores[ronda] {
+			t.Errorf("Se esperaba que la ronda %d empezara %s, pero empieza %s", ronda+1, jugadores[ronda].Nombre, partida.JugadorActual().Nombre)
+		}
+		partida.Jugar()
+	}
+	if marcador.Rondas != 2 || marcador.Puntos[0]+marcador.Puntos[1] != 0 || marcador.Puntos[0] == 0 {
+		t.Errorf("Se esperaban 2 rondas con puntos que suman cero, pero el marcador es %+v", marcador)
+	}
+}
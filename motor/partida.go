@@ -0,0 +1,748 @@
+// Package motor lleva una partida de Rummikub: reparte, da los turnos a las
+// estrategias de los jugadores, comprueba la mesa que dejan y aplica las reglas de
+// la casa.
+package motor
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand"
+	"sort"
+
+	"com.github/hapkiduki/rummikub/idioma"
+	"com.github/hapkiduki/rummikub/mazo"
+	"com.github/hapkiduki/rummikub/reglas"
+)
+
+// --- MOTOR DE LA PARTIDA ---
+
+// TipoMovimiento identifica la acción que un jugador elige en su turno.
+type TipoMovimiento string
+
+const (
+	MovimientoJugar  TipoMovimiento = "jugar"  // Bajar una jugada nueva a la mesa.
+	MovimientoAnadir TipoMovimiento = "anadir" // Añadir una ficha a una jugada de la mesa.
+	MovimientoRobar  TipoMovimiento = "robar"  // No bajar nada: el motor roba por el jugador al acabar el turno.
+)
+
+// Movimiento describe lo que un jugador quiere hacer en su turno.
+// Los índices hacen referencia a las fichas de la mano del jugador.
+type Movimiento struct {
+	Accion  TipoMovimiento `json:"accion"`
+	Indices []int          `json:"indices,omitempty"`
+	Jugada  int            `json:"jugada,omitempty"`
+}
+
+// AplicarMovimiento valida un movimiento con las reglas r y, si es legal, lo aplica sobre
+// la mano y la mesa. Si el movimiento no es válido se devuelve un error y no se modifica
+// nada. Robar no toca nada: es la Partida la que roba del pozo cuando el jugador no ha
+// bajado fichas.
+func (r ReglasCasa) AplicarMovimiento(jugador *Jugador, mesa [][]mazo.Pieza, mov Movimiento) ([][]mazo.Pieza, error) {
+	switch mov.Accion {
+	case MovimientoJugar:
+		fichas, indices, err := fichasDeMano(jugador.Mano, mov.Indices)
+		if err != nil {
+			return mesa, err
+		}
+		if err := r.Grupos.ValidarJugada(fichas); err != nil {
+			return mesa, err
+		}
+		if !jugador.HaHechoPrimeraJugada {
+			puntos := reglas.CalcularValorJugada(fichas)
+			if puntos < 30 {
+				return mesa, fmt.Errorf(idioma.T("tu primera jugada debe sumar 30 o más puntos, la tuya suma %d"), puntos)
+			}
+			jugador.HaHechoPrimeraJugada = true
+		}
+		mesa = append(mesa, fichas)
+		reglas.OrdenarJugada(mesa[len(mesa)-1])
+		jugador.Mano = reglas.QuitarFichasDeMano(jugador.Mano, indices)
+		return mesa, nil
+	case MovimientoAnadir:
+		if len(mov.Indices) != 1 {
+			return mesa, errors.New(idioma.T("solo puedes añadir una ficha a la vez"))
+		}
+		if !jugador.HaHechoPrimeraJugada {
+			return mesa, errors.New(idioma.T("debes hacer tu primera jugada antes de añadir fichas a la mesa"))
+		}
+		fichas, indices, err := fichasDeMano(jugador.Mano, mov.Indices)
+		if err != nil {
+			return mesa, err
+		}
+		if mov.Jugada < 0 || mov.Jugada >= len(mesa) {
+			return mesa, fmt.Errorf(idioma.T("la jugada %d no existe en la mesa"), mov.Jugada)
+		}
+		if !r.Grupos.SePuedeAnadirFicha(mesa[mov.Jugada], fichas[0]) {
+			if err := r.Grupos.ValidarJugada(append(append([]mazo.Pieza(nil), mesa[mov.Jugada]...), fichas[0])); err != nil {
+				return mesa, fmt.Errorf(idioma.T("la ficha %s no encaja en la jugada %d: %w"), fichas[0], mov.Jugada, err)
+			}
+			return mesa, fmt.Errorf(idioma.T("la jugada %d es un trío con dos comodines y no admite más fichas"), mov.Jugada)
+		}
+		mesa[mov.Jugada] = append(mesa[mov.Jugada], fichas[0])
+		reglas.OrdenarJugada(mesa[mov.Jugada])
+		jugador.Mano = reglas.QuitarFichasDeMano(jugador.Mano, indices)
+		return mesa, nil
+	case MovimientoRobar:
+		return mesa, nil
+	default:
+		return mesa, fmt.Errorf(idioma.T("acción desconocida '%s'"), mov.Accion)
+	}
+}
+
+// fichasDeMano devuelve las fichas de la mano que corresponden a los índices dados,
+// junto con el conjunto de índices listo para reglas.QuitarFichasDeMano.
+func fichasDeMano(mano []mazo.Pieza, indices []int) ([]mazo.Pieza, map[int]bool, error) {
+	if len(indices) == 0 {
+		return nil, nil, errors.New(idioma.T("no has seleccionado ninguna ficha"))
+	}
+	fichas := make([]mazo.Pieza, 0, len(indices))
+	seleccionados := make(map[int]bool)
+	for _, indice := range indices {
+		if indice < 0 || indice >= len(mano) {
+			return nil, nil, fmt.Errorf(idioma.T("el indice %d está fuera del rango de tu mano"), indice)
+		}
+		if seleccionados[indice] {
+			return nil, nil, fmt.Errorf(idioma.T("el indice %d fué seleccionado más de una vez"), indice)
+		}
+		seleccionados[indice] = true
+		fichas = append(fichas, mano[indice])
+	}
+	return fichas, seleccionados, nil
+}
+
+// OpcionesPartida ajustan cómo se crea y se narra una partida.
+type OpcionesPartida struct {
+	Rand         *rand.Rand // Fuente para barajar; nil usa la global. Con una semilla fija la partida es reproducible.
+	Silenciosa   bool       // Sin Narrador en la salida estándar (simulaciones).
+	Reglas       ReglasCasa
+	Observadores []Observador // Reciben todos los eventos, desde el reparto.
+	Primero      int          // Asiento que empieza, salvo que Reglas.SortearInicio lo sortee.
+}
+
+// ReglasCasa son variantes opcionales del reglamento. El valor cero es el juego estándar:
+// quien no baja ninguna ficha roba una y los movimientos inválidos se pueden repetir.
+type ReglasCasa struct {
+	// RobarHastaPoderJugar hace que el robo obligatorio siga hasta que el jugador tenga
+	// algo que jugar en su próximo turno o se acabe el pozo.
+	RobarHastaPoderJugar bool
+	// PenalizacionInvalida es cuántas fichas roba quien intenta un movimiento inválido;
+	// con la penalización su turno termina. 0 desactiva la regla.
+	PenalizacionInvalida int
+	// PenalizarMesaInvalida aplica la regla oficial: si al acabar el turno la mesa no es
+	// válida, el jugador recupera sus fichas, la mesa vuelve a como estaba y roba
+	// fichasPenalizacionMesa fichas. Sin ella, el turno se deshace igualmente y el
+	// jugador roba como si no hubiera jugado.
+	PenalizarMesaInvalida bool
+	// FichasIniciales es cuántas fichas recibe cada jugador en el reparto; 0 reparte
+	// FichasPorJugador.
+	FichasIniciales int
+	// SortearInicio decide quién empieza como en el reglamento oficial: cada jugador
+	// saca una ficha y empieza el del número más alto. Sin ella empieza
+	// OpcionesPartida.Primero.
+	SortearInicio bool
+	// Grupos son las variantes para tríos y cuartetas. El motor las pasa a cada
+	// comprobación del reglamento; el valor cero es el reglamento estándar.
+	Grupos reglas.ReglasGrupos
+}
+
+// fichasPenalizacionMesa son las fichas que se roban por dejar la mesa inválida.
+const fichasPenalizacionMesa = 3
+
+// Partida guarda el estado completo de un juego: jugadores, pozo, mesa y turno.
+type Partida struct {
+	Jugadores []*Jugador
+	Pozo      *mazo.Pozo
+	Mesa      [][]mazo.Pieza
+	Turno     int
+	Primero   int // Asiento del jugador que empezó la partida.
+	Terminada bool
+	Ganador   *Jugador
+	// MazoAgotado indica que la partida acabó porque se terminaron las fichas del mazo.
+	MazoAgotado  bool
+	Reglas       ReglasCasa
+	penalizado   bool // El jugador actual ya ha robado como penalización este turno.
+	observadores []Observador
+}
+
+// NuevaPartida baraja un mazo nuevo, sortea quién empieza si las reglas lo piden,
+// reparte las fichas desde el pozo y deja la partida lista para jugar. Con la misma
+// fuente de Rand, todo el proceso da siempre el mismo resultado.
+func NuevaPartida(jugadores []*Jugador, opciones OpcionesPartida) *Partida {
+	fichas := mazo.Completo()
+	barajar := rand.Shuffle
+	if opciones.Rand != nil {
+		barajar = opciones.Rand.Shuffle
+	}
+	barajar(len(fichas), func(i, j int) { fichas[i], fichas[j] = fichas[j], fichas[i] })
+	p := &Partida{
+		Jugadores: jugadores,
+		Pozo:      mazo.NuevoPozo(fichas), // Antes del reparto todas las fichas están en el pozo.
+		// La mesa se crea UNA VEZ y se comparte durante toda la partida.
+		Mesa:    make([][]mazo.Pieza, 0),
+		Reglas:  opciones.Reglas,
+		Primero: opciones.Primero % len(jugadores),
+	}
+	if !opciones.Silenciosa {
+		p.Observar(Narrador{})
+	}
+	for _, o := range opciones.Observadores {
+		p.Observar(o)
+	}
+	nombres := make([]string, len(jugadores))
+	for i, j := range jugadores {
+		nombres[i] = j.Nombre
+	}
+	p.emitir(PartidaIniciada{Jugadores: nombres})
+	if p.Reglas.SortearInicio {
+		var sorteo []FichaSorteo
+		p.Primero, sorteo = sortearInicio(jugadores, p.Pozo, barajar)
+		p.emitir(InicioSorteado{Fichas: sorteo, Jugador: jugadores[p.Primero].Nombre})
+	}
+	n := p.Reglas.FichasIniciales
+	if n <= 0 {
+		n = FichasPorJugador
+	}
+	repartir(jugadores, p.Pozo, n)
+	manos := make([][]mazo.Pieza, len(jugadores))
+	for i, j := range jugadores {
+		manos[i] = append([]mazo.Pieza(nil), j.Mano...)
+	}
+	p.emitir(FichasRepartidas{Manos: manos, FichasEnPozo: p.Pozo.Restantes()})
+	return p
+}
+
+// JugadorActual devuelve el jugador al que le toca jugar.
+func (p *Partida) JugadorActual() *Jugador {
+	return p.Jugadores[(p.Primero+p.Turno)%len(p.Jugadores)]
+}
+
+// JugarTurno deja jugar al jugador actual y comprueba si la partida ha terminado.
+func (p *Partida) JugarTurno() {
+	if p.Terminada {
+		return
+	}
+	jugadorActual := p.JugadorActual()
+	p.emitir(TurnoIniciado{Turno: p.Turno, Jugador: jugadorActual.Nombre})
+	if pensativa, ok := jugadorActual.Estrategia.(EstrategiaPensativa); ok && pensativa.Piensa() {
+		p.emitir(JugadorPensando{Turno: p.Turno, Jugador: jugadorActual.Nombre})
+	}
+	inicio := p.guardarInicioTurno(jugadorActual)
+	p.penalizado = false
+	p.Mesa = jugadorActual.Estrategia.JugarTurno(jugadorActual, p.Mesa)
+	// Una estrategia que pierde, duplica o inventa fichas ha dejado una mesa inválida
+	// como cualquier otra: se deshace el turno con las fichas del principio.
+	err := p.comprobarFichas()
+	if err == nil {
+		err = inicio.Validar(p.Reglas.Grupos, jugadorActual, p.Mesa, p.robadasDesde(inicio))
+	}
+	if err != nil {
+		p.deshacerTurno(jugadorActual, inicio, err)
+	} else {
+		// Quien decide si el jugador ha abierto es el motor, no la estrategia: en un
+		// turno válido, bajar fichas sin haber abierto es la primera jugada.
+		jugadorActual.HaHechoPrimeraJugada = inicio.Abierto || len(jugadorActual.Mano) < len(inicio.Mano)+len(p.robadasDesde(inicio))
+		p.emitirJugadas(jugadorActual, inicio)
+	}
+	if len(jugadorActual.Mano) >= len(inicio.Mano)+len(p.robadasDesde(inicio)) && !p.penalizado {
+		p.roboObligatorio(jugadorActual)
+	}
+	if len(jugadorActual.Mano) == 0 {
+		p.Ganador = jugadorActual
+		p.Terminada = true
+	} else if p.Pozo.Restantes() == 0 {
+		p.MazoAgotado = true
+		// Determinar el ganador: el que tenga menos puntos en su mano.
+		minPuntos := 9999
+		for _, j := range p.Jugadores {
+			if puntos := reglas.CalcularPuntosMano(j.Mano); puntos < minPuntos {
+				minPuntos = puntos
+				p.Ganador = j
+			}
+		}
+		p.Terminada = true
+	}
+	if p.Terminada {
+		fin := PartidaTerminada{Turno: p.Turno, Ganador: p.Ganador.Nombre, MazoAgotado: p.MazoAgotado}
+		for _, j := range p.Jugadores {
+			fin.Jugadores = append(fin.Jugadores, ResultadoJugador{Nombre: j.Nombre, Puntos: reglas.CalcularPuntosMano(j.Mano), PistasUsadas: j.PistasUsadas})
+		}
+		p.emitir(fin)
+	}
+	p.Turno++
+}
+
+// emitirJugadas cuenta lo que el jugador ha bajado en un turno válido comparando la
+// mesa con la del inicio: las jugadas hechas solo con fichas de la mano son jugadas
+// nuevas, y las fichas de la mano que acaban junto a fichas de la mesa, añadidas.
+func (p *Partida) emitirJugadas(jugador *Jugador, inicio InicioTurno) {
+	deLaMano := make(map[int]bool, len(inicio.Mano))
+	for _, ficha := range inicio.Mano {
+		deLaMano[ficha.ID] = true
+	}
+	if jugador.HaHechoPrimeraJugada && !inicio.Abierto {
+		p.emitir(JugadorAbrio{Turno: p.Turno, Jugador: jugador.Nombre, Puntos: inicio.puntosNuevos(p.Mesa)})
+	}
+	for i, jugada := range p.Mesa {
+		nuevas := make([]mazo.Pieza, 0, len(jugada))
+		for _, ficha := range jugada {
+			if deLaMano[ficha.ID] {
+				nuevas = append(nuevas, ficha)
+			}
+		}
+		switch {
+		case len(nuevas) == 0:
+		case len(nuevas) == len(jugada):
+			p.emitir(JugadaBajada{Turno: p.Turno, Jugador: jugador.Nombre, Fichas: append([]mazo.Pieza(nil), jugada...), Jugada: i})
+		default:
+			for _, ficha := range nuevas {
+				p.emitir(FichaAnadida{Turno: p.Turno, Jugador: jugador.Nombre, Ficha: ficha, Jugada: i})
+			}
+		}
+	}
+}
+
+// InicioTurno es una copia de lo que el jugador puede cambiar en su turno, para
+// comprobar el resultado y poder deshacerlo.
+type InicioTurno struct {
+	Mesa    [][]mazo.Pieza
+	Mano    []mazo.Pieza
+	Abierto bool // Si el jugador ya había hecho su primera jugada.
+	robos   int  // Longitud del historial del pozo al empezar el turno.
+}
+
+// guardarInicioTurno copia la mesa jugada a jugada, porque las estrategias ordenan y
+// amplían las jugadas en el sitio.
+func (p *Partida) guardarInicioTurno(jugador *Jugador) InicioTurno {
+	inicio := FotoDelTurno(jugador, p.Mesa)
+	inicio.robos = len(p.Pozo.Historial())
+	return inicio
+}
+
+// FotoDelTurno copia la mesa y la mano del jugador tal como están ahora.
+func FotoDelTurno(jugador *Jugador, mesa [][]mazo.Pieza) InicioTurno {
+	return InicioTurno{
+		Mesa:    CopiarMesa(mesa),
+		Mano:    append([]mazo.Pieza(nil), jugador.Mano...),
+		Abierto: jugador.HaHechoPrimeraJugada,
+	}
+}
+
+// CopiarMesa copia la mesa jugada a jugada.
+func CopiarMesa(mesa [][]mazo.Pieza) [][]mazo.Pieza {
+	copia := make([][]mazo.Pieza, len(mesa))
+	for i, jugada := range mesa {
+		copia[i] = append([]mazo.Pieza(nil), jugada...)
+	}
+	return copia
+}
+
+// robadasDesde devuelve las fichas robadas del pozo durante el turno, por ejemplo
+// como penalización por un intento inválido.
+func (p *Partida) robadasDesde(inicio InicioTurno) []mazo.Pieza {
+	robadas := make([]mazo.Pieza, 0)
+	for _, robo := range p.Pozo.Historial()[inicio.robos:] {
+		robadas = append(robadas, robo.Ficha)
+	}
+	return robadas
+}
+
+// Validar comprueba que el jugador ha dejado la mesa como manda el reglamento: todas
+// las jugadas válidas, ninguna ficha de la mesa en su mano (salvo las robadas en el
+// turno) y, si aún no había abierto, las jugadas de la mesa intactas y 30 puntos o
+// más en jugadas nuevas. Dar por hecha la primera jugada sin bajar fichas tampoco vale.
+// Las jugadas se comprueban con las variantes de grupos de la partida.
+func (i InicioTurno) Validar(grupos reglas.ReglasGrupos, jugador *Jugador, mesa [][]mazo.Pieza, robadas []mazo.Pieza) error {
+	for j, jugada := range mesa {
+		if err := grupos.ValidarJugada(jugada); err != nil {
+			return fmt.Errorf(idioma.T("la jugada %d de la mesa no es válida: %w"), j, err)
+		}
+	}
+	enMano := make(map[int]bool, len(i.Mano)+len(robadas))
+	for _, ficha := range append(robadas, i.Mano...) {
+		enMano[ficha.ID] = true
+	}
+	for _, ficha := range jugador.Mano {
+		if !enMano[ficha.ID] {
+			return fmt.Errorf(idioma.T("la ficha %s ha pasado de la mesa a la mano"), ficha)
+		}
+	}
+	if cerrado := i.trioCerradoAmpliado(grupos, mesa); cerrado != nil {
+		return fmt.Errorf(idioma.T("el trío %v tiene dos comodines y no admite más fichas"), cerrado)
+	}
+	if i.Abierto {
+		return nil
+	}
+	// Sin haber abierto solo se pueden añadir jugadas nuevas con fichas propias; la
+	// mesa no se puede reorganizar aunque no se baje ninguna ficha.
+	intactas := make(map[string]bool, len(mesa))
+	for _, jugada := range mesa {
+		intactas[claveJugada(jugada)] = true
+	}
+	for _, jugada := range i.Mesa {
+		if !intactas[claveJugada(jugada)] {
+			return errors.New(idioma.T("no puedes tocar las jugadas de la mesa antes de tu primera jugada"))
+		}
+	}
+	if len(jugador.Mano) == len(i.Mano)+len(robadas) {
+		if jugador.HaHechoPrimeraJugada {
+			return errors.New(idioma.T("no has hecho tu primera jugada: no has bajado ninguna ficha"))
+		}
+		return nil
+	}
+	// Con las jugadas de la mesa intactas, las nuevas solo llevan fichas de la mano.
+	if puntos := i.puntosNuevos(mesa); puntos < 30 {
+		return fmt.Errorf(idioma.T("tu primera jugada debe sumar 30 o más puntos, la tuya suma %d"), puntos)
+	}
+	return nil
+}
+
+// puntosNuevos suma las jugadas de mesa que no estaban al empezar el turno.
+func (i InicioTurno) puntosNuevos(mesa [][]mazo.Pieza) int {
+	anteriores := make(map[string]bool, len(i.Mesa))
+	for _, jugada := range i.Mesa {
+		anteriores[claveJugada(jugada)] = true
+	}
+	puntos := 0
+	for _, jugada := range mesa {
+		if !anteriores[claveJugada(jugada)] {
+			puntos += reglas.CalcularValorJugada(jugada)
+		}
+	}
+	return puntos
+}
+
+// trioCerradoAmpliado devuelve el trío con dos comodines de la mesa del principio del
+// turno que ha acabado dentro de un grupo más grande, algo que prohíbe la variante
+// CerrarConDosComodines aunque se haga moviendo fichas de la mesa. Devuelve nil si no
+// hay ninguno.
+func (i InicioTurno) trioCerradoAmpliado(grupos reglas.ReglasGrupos, mesa [][]mazo.Pieza) []mazo.Pieza {
+	for _, cerrado := range i.Mesa {
+		if grupos.SePuedeAmpliarGrupo(cerrado) {
+			continue
+		}
+		for _, jugada := range mesa {
+			if len(jugada) > len(cerrado) && grupos.EsTrioValido(append([]mazo.Pieza(nil), jugada...)) && contieneFichas(jugada, cerrado) {
+				return cerrado
+			}
+		}
+	}
+	return nil
+}
+
+// contieneFichas indica si todas las fichas de parte, por ID, están en jugada.
+func contieneFichas(jugada, parte []mazo.Pieza) bool {
+	ids := make(map[int]bool, len(jugada))
+	for _, ficha := range jugada {
+		ids[ficha.ID] = true
+	}
+	for _, ficha := range parte {
+		if !ids[ficha.ID] {
+			return false
+		}
+	}
+	return true
+}
+
+// claveJugada identifica una jugada por los IDs de sus fichas, sin importar el orden.
+func claveJugada(jugada []mazo.Pieza) string {
+	ids := make([]int, len(jugada))
+	for i, ficha := range jugada {
+		ids[i] = ficha.ID
+	}
+	sort.Ints(ids)
+	return fmt.Sprint(ids)
+}
+
+// deshacerTurno devuelve la mesa y la mano del jugador a como estaban al empezar el
+// turno y, con PenalizarMesaInvalida, le hace robar las fichas de penalización.
+func (p *Partida) deshacerTurno(jugador *Jugador, inicio InicioTurno, motivo error) {
+	p.Mesa = inicio.Mesa
+	jugador.Mano = append(inicio.Mano, p.robadasDesde(inicio)...)
+	jugador.HaHechoPrimeraJugada = inicio.Abierto
+	p.emitir(MesaInvalida{Turno: p.Turno, Jugador: jugador.Nombre, Motivo: motivo.Error(), Penalizada: p.Reglas.PenalizarMesaInvalida})
+	if !p.Reglas.PenalizarMesaInvalida {
+		return
+	}
+	robadas := p.robar(jugador, fichasPenalizacionMesa, mazo.RoboPenalizacion)
+	p.penalizado = true
+	p.emitir(FichasRobadas{Turno: p.Turno, Jugador: jugador.Nombre, Fichas: robadas, Motivo: mazo.RoboPenalizacion})
+}
+
+// roboObligatorio hace robar al jugador que no ha bajado ninguna ficha: una, o con
+// RobarHastaPoderJugar, hasta que tenga algo que jugar.
+func (p *Partida) roboObligatorio(jugador *Jugador) {
+	robadas := p.robar(jugador, 1, mazo.RoboObligatorio)
+	for p.Reglas.RobarHastaPoderJugar && len(robadas) > 0 && !puedeJugar(p.Reglas.Grupos, jugador, p.Mesa) {
+		robadas = append(robadas, p.robar(jugador, 1, mazo.RoboObligatorio)...)
+	}
+	p.emitir(FichasRobadas{Turno: p.Turno, Jugador: jugador.Nombre, Fichas: robadas, Motivo: mazo.RoboObligatorio})
+}
+
+// robar pasa hasta n fichas del pozo a la mano del jugador y avisa a su estrategia
+// si quiere saber cuáles son. Devuelve las fichas robadas; quien llama emite el robo.
+func (p *Partida) robar(jugador *Jugador, n int, motivo mazo.MotivoRobo) []mazo.Pieza {
+	robadas := make([]mazo.Pieza, 0, n)
+	for len(robadas) < n {
+		ficha, ok := p.Pozo.Robar(jugador.Nombre, p.Turno, motivo)
+		if !ok {
+			break
+		}
+		robadas = append(robadas, ficha)
+	}
+	jugador.Mano = append(jugador.Mano, robadas...)
+	if aviso, ok := jugador.Estrategia.(AvisoRobo); ok && len(robadas) > 0 {
+		aviso.FichasRobadas(jugador, robadas)
+	}
+	return robadas
+}
+
+// IntentoInvalido aplica la regla de la casa PenalizacionInvalida después de que un
+// movimiento del jugador haya sido rechazado. Devuelve true si el jugador ha sido
+// penalizado y su turno debe terminar. Se puede llamar sobre una Partida nil (sin reglas).
+func (p *Partida) IntentoInvalido(jugador *Jugador) bool {
+	if p == nil || p.Reglas.PenalizacionInvalida <= 0 {
+		return false
+	}
+	robadas := p.robar(jugador, p.Reglas.PenalizacionInvalida, mazo.RoboPenalizacion)
+	p.penalizado = true
+	p.emitir(FichasRobadas{Turno: p.Turno, Jugador: jugador.Nombre, Fichas: robadas, Motivo: mazo.RoboPenalizacion})
+	return true
+}
+
+// AvisoRobo lo implementan las estrategias que quieren saber qué fichas ha robado su
+// jugador, por ejemplo para enseñárselas a un humano.
+type AvisoRobo interface {
+	FichasRobadas(jugador *Jugador, fichas []mazo.Pieza)
+}
+
+// puedeJugar indica si el jugador tiene alguna jugada legal: una jugada en la mano (de
+// 30 puntos o más si aún no ha abierto) o, tras abrir, una ficha que encaje en la mesa.
+func puedeJugar(grupos reglas.ReglasGrupos, jugador *Jugador, mesa [][]mazo.Pieza) bool {
+	jugadas := grupos.JugadasPosibles(jugador.Mano)
+	if !jugador.HaHechoPrimeraJugada {
+		apertura := reglas.MejorJugada(jugadas, true)
+		return apertura != nil && reglas.CalcularValorJugada(apertura) >= 30
+	}
+	if len(jugadas) > 0 {
+		return true
+	}
+	for _, ficha := range jugador.Mano {
+		for _, jugada := range mesa {
+			if grupos.SePuedeAnadirFicha(jugada, ficha) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// mazoCompleto son las 106 fichas del juego sin barajar: mazoCompleto[id-1] es la
+// ficha con ese ID. juegoCompleto es el mismo mazo contado por valor.
+var (
+	mazoCompleto  = mazo.Completo()
+	juegoCompleto = mazo.NuevoConjunto(mazoCompleto)
+)
+
+// todasLasFichas junta las fichas del pozo, de todas las manos y de la mesa.
+func (p *Partida) todasLasFichas() []mazo.Pieza {
+	todas := p.Pozo.Fichas()
+	for _, j := range p.Jugadores {
+		todas = append(todas, j.Mano...)
+	}
+	for _, jugada := range p.Mesa {
+		todas = append(todas, jugada...)
+	}
+	return todas
+}
+
+// comprobarFichas verifica que entre manos, mesa y pozo estén las 106 fichas, cada
+// una una sola vez y con el ID que le dio mazo.Completo.
+func (p *Partida) comprobarFichas() error {
+	todas := p.todasLasFichas()
+	if enJuego := mazo.NuevoConjunto(todas); enJuego != juegoCompleto {
+		return fmt.Errorf(idioma.T("sobran %v y faltan %v"), enJuego.Diferencia(juegoCompleto).Fichas(), juegoCompleto.Diferencia(enJuego).Fichas())
+	}
+	vistas := make([]bool, len(mazoCompleto)+1)
+	for _, f := range todas {
+		if f.ID < 1 || f.ID > len(mazoCompleto) || mazoCompleto[f.ID-1] != f {
+			return fmt.Errorf(idioma.T("la ficha %s tiene un ID que no le corresponde (%d)"), f, f.ID)
+		}
+		if vistas[f.ID] {
+			return fmt.Errorf(idioma.T("la ficha %s con ID %d aparece dos veces"), f, f.ID)
+		}
+		vistas[f.ID] = true
+	}
+	return nil
+}
+
+// Jugar ejecuta turnos hasta que la partida termina.
+func (p *Partida) Jugar() {
+	for !p.Terminada {
+		p.JugarTurno()
+	}
+}
+
+// EstadoJugador es la información pública de un jugador que todos pueden ver.
+type EstadoJugador struct {
+	Nombre               string `json:"nombre"`
+	Fichas               int    `json:"fichas"`
+	HaHechoPrimeraJugada bool   `json:"ha_hecho_primera_jugada"`
+}
+
+// EstadoPartida es una foto de la partida vista por un jugador concreto:
+// todo lo público más su propia mano.
+type EstadoPartida struct {
+	Turno         int             `json:"turno"`
+	JugadorActual string          `json:"jugador_actual"`
+	Mesa          [][]mazo.Pieza  `json:"mesa"`
+	FichasEnMazo  int             `json:"fichas_en_mazo"`
+	Jugadores     []EstadoJugador `json:"jugadores"`
+	Mano          []mazo.Pieza    `json:"mano,omitempty"`
+	Terminada     bool            `json:"terminada"`
+	Ganador       string          `json:"ganador,omitempty"`
+}
+
+// Estado construye la vista de la partida para un jugador. Si para es nil
+// no se incluye ninguna mano (vista de espectador).
+func (p *Partida) Estado(para *Jugador) EstadoPartida {
+	return p.EstadoDuranteTurno(para, p.Mesa)
+}
+
+// EstadoDuranteTurno es como Estado, pero con la mesa que la estrategia del
+// jugador actual lleva a mitad de turno. Sirve para enseñar una jugada ya aplicada
+// antes de que el motor valide el turno, sin tocar p.Mesa.
+func (p *Partida) EstadoDuranteTurno(para *Jugador, mesa [][]mazo.Pieza) EstadoPartida {
+	estado := EstadoPartida{
+		Turno:         p.Turno,
+		JugadorActual: p.JugadorActual().Nombre,
+		Mesa:          make([][]mazo.Pieza, len(mesa)),
+		FichasEnMazo:  p.Pozo.Restantes(),
+		Terminada:     p.Terminada,
+	}
+	// Copiamos la mesa y la mano: la foto no debe cambiar cuando la partida avanza.
+	for i, jugada := range mesa {
+		estado.Mesa[i] = append([]mazo.Pieza(nil), jugada...)
+	}
+	for _, j := range p.Jugadores {
+		estado.Jugadores = append(estado.Jugadores, EstadoJugador{
+			Nombre:               j.Nombre,
+			Fichas:               len(j.Mano),
+			HaHechoPrimeraJugada: j.HaHechoPrimeraJugada,
+		})
+	}
+	if para != nil {
+		estado.Mano = append([]mazo.Pieza(nil), para.Mano...)
+	}
+	if p.Ganador != nil {
+		estado.Ganador = p.Ganador.Nombre
+	}
+	return estado
+}
+
+// --- PARTIDAS GUARDADAS ---
+
+// PartidaGuardada es una foto de la partida entre dos turnos, con las fichas en
+// notación corta y su ID (R7#12) para que el archivo se pueda leer y editar a mano
+// sin perder qué copia de cada ficha es cuál.
+type PartidaGuardada struct {
+	Turno     int               `json:"turno"`
+	Primero   int               `json:"primero"`
+	Mesa      []string          `json:"mesa"`
+	Pozo      string            `json:"pozo"`
+	Jugadores []JugadorGuardado `json:"jugadores"`
+}
+
+// JugadorGuardado es la parte de un jugador en una PartidaGuardada.
+type JugadorGuardado struct {
+	Nombre  string `json:"nombre"`
+	Mano    string `json:"mano"`
+	Abierto bool   `json:"abierto"`
+}
+
+// Guardada devuelve la foto de la partida para guardarla en un archivo.
+func (p *Partida) Guardada() PartidaGuardada {
+	guardada := PartidaGuardada{
+		Turno:   p.Turno,
+		Primero: p.Primero,
+		Mesa:    make([]string, len(p.Mesa)),
+		Pozo:    mazo.NotacionFichasConID(p.Pozo.Fichas()),
+	}
+	for i, jugada := range p.Mesa {
+		guardada.Mesa[i] = mazo.NotacionFichasConID(jugada)
+	}
+	for _, j := range p.Jugadores {
+		guardada.Jugadores = append(guardada.Jugadores, JugadorGuardado{Nombre: j.Nombre, Mano: mazo.NotacionFichasConID(j.Mano), Abierto: j.HaHechoPrimeraJugada})
+	}
+	return guardada
+}
+
+// Cargar rehace una partida guardada con Guardada para seguir jugándola. Los
+// jugadores van en el orden de los asientos guardados y solo aportan su estrategia:
+// el nombre, la mano y si han abierto salen del archivo. Las fichas del archivo
+// conservan sus IDs, que no se pueden repetir, y deben ser exactamente las 106 del juego.
+func Cargar(g PartidaGuardada, jugadores []*Jugador, opciones OpcionesPartida) (*Partida, error) {
+	if len(g.Jugadores) != len(jugadores) {
+		return nil, fmt.Errorf(idioma.T("la partida guardada es de %d jugadores, no de %d"), len(g.Jugadores), len(jugadores))
+	}
+	if g.Primero < 0 || g.Primero >= len(jugadores) || g.Turno < 0 {
+		return nil, errors.New(idioma.T("la partida guardada no indica bien de quién es el turno"))
+	}
+	usadas := make(map[int]bool, len(mazoCompleto))
+	// leer convierte la notación en fichas y comprueba que ningún ID sale dos veces.
+	// Que cada ID sea el de su ficha lo comprueba comprobarFichas al final.
+	leer := func(notacion string) ([]mazo.Pieza, error) {
+		fichas, err := mazo.ParsearFichasConID(notacion)
+		if err != nil {
+			return nil, err
+		}
+		for _, ficha := range fichas {
+			if usadas[ficha.ID] {
+				return nil, fmt.Errorf(idioma.T("la ficha %s con ID %d aparece dos veces"), ficha, ficha.ID)
+			}
+			usadas[ficha.ID] = true
+		}
+		return fichas, nil
+	}
+	p := &Partida{Jugadores: jugadores, Turno: g.Turno, Primero: g.Primero, Mesa: make([][]mazo.Pieza, 0, len(g.Mesa)), Reglas: opciones.Reglas}
+	for _, notacion := range g.Mesa {
+		jugada, err := leer(notacion)
+		if err != nil {
+			return nil, err
+		}
+		p.Mesa = append(p.Mesa, jugada)
+	}
+	for i, guardado := range g.Jugadores {
+		mano, err := leer(guardado.Mano)
+		if err != nil {
+			return nil, err
+		}
+		jugadores[i].Nombre, jugadores[i].Mano, jugadores[i].HaHechoPrimeraJugada = guardado.Nombre, mano, guardado.Abierto
+	}
+	pozo, err := leer(g.Pozo)
+	if err != nil {
+		return nil, err
+	}
+	p.Pozo = mazo.NuevoPozo(pozo)
+	if err := p.comprobarFichas(); err != nil {
+		return nil, fmt.Errorf(idioma.T("la partida guardada no tiene las 106 fichas: %v"), err)
+	}
+	if !opciones.Silenciosa {
+		p.Observar(Narrador{})
+	}
+	for _, o := range opciones.Observadores {
+		p.Observar(o)
+	}
+	return p, nil
+}
+
+// HashEstado resume todo el estado de la partida (turno, mesa, pozo en orden, manos y
+// quién ha abierto, con el ID de cada ficha) en un hash SHA-256 en hexadecimal. Dos partidas en el mismo
+// estado dan el mismo hash, así que sirve para comparar registros.
+func (p *Partida) HashEstado() string {
+	datos, _ := json.Marshal(p.Guardada())
+	suma := sha256.Sum256(datos)
+	return hex.EncodeToString(suma[:])
+}
@@ -0,0 +1,329 @@
+package motor
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+
+	"com.github/hapkiduki/rummikub/mazo"
+	"com.github/hapkiduki/rummikub/reglas"
+)
+
+// estrategiaFunc permite escribir estrategias de prueba como funciones.
+type estrategiaFunc func(jugador *Jugador, mesa [][]mazo.Pieza) [][]mazo.Pieza
+
+func (f estrategiaFunc) JugarTurno(jugador *Jugador, mesa [][]mazo.Pieza) [][]mazo.Pieza {
+	return f(jugador, mesa)
+}
+
+// estrategiaRobadora nunca baja nada, así que siempre roba.
+type estrategiaRobadora struct{}
+
+func (estrategiaRobadora) JugarTurno(jugador *Jugador, mesa [][]mazo.Pieza) [][]mazo.Pieza {
+	return mesa
+}
+
+// fichasDe lee fichas en notación corta para preparar las pruebas.
+func fichasDe(t testing.TB, notacion string) []mazo.Pieza {
+	t.Helper()
+	fichas, err := mazo.ParsearFichas(notacion)
+	if err != nil {
+		t.Fatalf("notación inválida en la prueba: %v", err)
+	}
+	return fichas
+}
+
+// sacarFichas quita la primera copia de cada ficha indicada (por valor) para preparar
+// la mesa de una prueba. Si ya no está en el pozo, la saca de una mano y le da a ese
+// jugador otra del pozo a cambio, para que todos sigan con las mismas fichas.
+func sacarFichas(p *Partida, fichas ...mazo.Pieza) []mazo.Pieza {
+	quitar := func(lista []mazo.Pieza, ficha mazo.Pieza) ([]mazo.Pieza, mazo.Pieza, bool) {
+		for i, f := range lista {
+			if f.MismoValor(ficha) {
+				return append(lista[:i], lista[i+1:]...), f, true
+			}
+		}
+		return lista, mazo.Pieza{}, false
+	}
+	pozo := p.Pozo.Fichas()
+	sacadas := make([]mazo.Pieza, 0, len(fichas))
+	for _, ficha := range fichas {
+		var sacada mazo.Pieza
+		var ok bool
+		if pozo, sacada, ok = quitar(pozo, ficha); !ok {
+			for _, j := range p.Jugadores {
+				if j.Mano, sacada, ok = quitar(j.Mano, ficha); ok {
+					j.Mano = append(j.Mano, pozo[0])
+					pozo = pozo[1:]
+					break
+				}
+			}
+		}
+		sacadas = append(sacadas, sacada)
+	}
+	p.Pozo = mazo.NuevoPozo(pozo)
+	return sacadas
+}
+
+func TestMesaInvalida(t *testing.T) {
+	escalera := fichasDe(t, "R1 R2 R3 R4 R5 R6")
+	casosDePrueba := []struct {
+		nombre          string
+		penalizar       bool
+		estrategia      estrategiaFunc
+		fichasEsperadas int // En la mano de Ana al acabar el turno (empieza con 14).
+		jugadasEnMesa   int // La mesa empieza con la escalera del rojo 1 al 6.
+		motivo          mazo.MotivoRobo
+	}{
+		{
+			nombre: "Dos fichas sueltas: se deshace el turno y roba una",
+			estrategia: func(j *Jugador, mesa [][]mazo.Pieza) [][]mazo.Pieza {
+				mesa = append(mesa, append([]mazo.Pieza(nil), j.Mano[:2]...))
+				j.Mano = j.Mano[2:]
+				return mesa
+			},
+			fichasEsperadas: 15,
+			jugadasEnMesa:   1,
+			motivo:          mazo.RoboObligatorio,
+		},
+		{
+			nombre:    "Dos fichas sueltas con penalización: roba tres",
+			penalizar: true,
+			estrategia: func(j *Jugador, mesa [][]mazo.Pieza) [][]mazo.Pieza {
+				mesa = append(mesa, append([]mazo.Pieza(nil), j.Mano[:2]...))
+				j.Mano = j.Mano[2:]
+				return mesa
+			},
+			fichasEsperadas: 17,
+			jugadasEnMesa:   1,
+			motivo:          mazo.RoboPenalizacion,
+		},
+		{
+			nombre:    "Quedarse una ficha de la mesa",
+			penalizar: true,
+			estrategia: func(j *Jugador, mesa [][]mazo.Pieza) [][]mazo.Pieza {
+				j.Mano = append(j.Mano, mesa[0][5])
+				mesa[0] = mesa[0][:5]
+				return mesa
+			},
+			fichasEsperadas: 17,
+			jugadasEnMesa:   1,
+			motivo:          mazo.RoboPenalizacion,
+		},
+		{
+			nombre:    "Duplicar una ficha de la mesa",
+			penalizar: true,
+			estrategia: func(j *Jugador, mesa [][]mazo.Pieza) [][]mazo.Pieza {
+				j.Mano = append(j.Mano, mesa[0][0])
+				return mesa
+			},
+			fichasEsperadas: 17,
+			jugadasEnMesa:   1,
+			motivo:          mazo.RoboPenalizacion,
+		},
+		{
+			nombre:    "Perder una ficha de la mano",
+			penalizar: true,
+			estrategia: func(j *Jugador, mesa [][]mazo.Pieza) [][]mazo.Pieza {
+				j.Mano = j.Mano[1:]
+				return mesa
+			},
+			fichasEsperadas: 17,
+			jugadasEnMesa:   1,
+			motivo:          mazo.RoboPenalizacion,
+		},
+		{
+			nombre:    "Partir una escalera en dos es válido",
+			penalizar: true,
+			estrategia: func(j *Jugador, mesa [][]mazo.Pieza) [][]mazo.Pieza {
+				return [][]mazo.Pieza{mesa[0][:3], mesa[0][3:]}
+			},
+			fichasEsperadas: 15,
+			jugadasEnMesa:   2,
+			motivo:          mazo.RoboObligatorio,
+		},
+	}
+
+	for _, tc := range casosDePrueba {
+		t.Run(tc.nombre, func(t *testing.T) {
+			ana := &Jugador{Nombre: "Ana", Estrategia: tc.estrategia, HaHechoPrimeraJugada: true}
+			jugadores := []*Jugador{ana, {Nombre: "Luis", Estrategia: estrategiaRobadora{}}}
+			partida := NuevaPartida(jugadores, OpcionesPartida{Rand: rand.New(rand.NewSource(1)), Silenciosa: true, Reglas: ReglasCasa{PenalizarMesaInvalida: tc.penalizar}})
+			partida.Mesa = append(partida.Mesa, sacarFichas(partida, escalera...))
+			mesaInicial := claveJugada(partida.Mesa[0])
+			partida.JugarTurno()
+
+			if len(ana.Mano) != tc.fichasEsperadas {
+				t.Errorf("Se esperaban %d fichas en la mano, pero hay %d", tc.fichasEsperadas, len(ana.Mano))
+			}
+			if len(partida.Mesa) != tc.jugadasEnMesa {
+				t.Errorf("Se esperaban %d jugadas en la mesa, pero hay %d", tc.jugadasEnMesa, len(partida.Mesa))
+			}
+			if tc.jugadasEnMesa == 1 && claveJugada(partida.Mesa[0]) != mesaInicial {
+				t.Errorf("Se esperaba la mesa como al empezar el turno, pero fue %v", partida.Mesa)
+			}
+			for _, robo := range partida.Pozo.Historial() {
+				if robo.Motivo != tc.motivo {
+					t.Errorf("Se esperaba robar por %s, pero fue %+v", tc.motivo, robo)
+				}
+			}
+		})
+	}
+}
+
+func TestPrimeraJugadaSinTocarLaMesa(t *testing.T) {
+	ana := &Jugador{Nombre: "Ana"}
+	jugadores := []*Jugador{ana, {Nombre: "Luis", Estrategia: estrategiaRobadora{}}}
+	partida := NuevaPartida(jugadores, OpcionesPartida{Rand: rand.New(rand.NewSource(1)), Silenciosa: true})
+	partida.Mesa = append(partida.Mesa, sacarFichas(partida, fichasDe(t, "B10 B11 B12")...))
+	ana.Mano = append(ana.Mano, sacarFichas(partida, fichasDe(t, "B13")...)...)
+	// Ana completa una escalera ajena para abrir: no está permitido antes de su primera jugada.
+	ana.Estrategia = estrategiaFunc(func(j *Jugador, mesa [][]mazo.Pieza) [][]mazo.Pieza {
+		mesa[0] = append(mesa[0], j.Mano[len(j.Mano)-1])
+		j.Mano = j.Mano[:len(j.Mano)-1]
+		j.HaHechoPrimeraJugada = true
+		return mesa
+	})
+	partida.JugarTurno()
+	if ana.HaHechoPrimeraJugada || len(partida.Mesa[0]) != 3 || len(ana.Mano) != 16 {
+		t.Errorf("Se esperaba deshacer la apertura, pero la mesa es %v y Ana tiene %d fichas", partida.Mesa, len(ana.Mano))
+	}
+}
+
+func TestReorganizarSinAbrir(t *testing.T) {
+	ana := &Jugador{Nombre: "Ana"}
+	jugadores := []*Jugador{ana, {Nombre: "Luis", Estrategia: estrategiaRobadora{}}}
+	partida := NuevaPartida(jugadores, OpcionesPartida{Rand: rand.New(rand.NewSource(1)), Silenciosa: true})
+	partida.Mesa = append(partida.Mesa, sacarFichas(partida, fichasDe(t, "R1 R2 R3 R4 R5 R6")...))
+	// Ana parte una escalera ajena sin bajar ninguna ficha: tampoco está permitido.
+	ana.Estrategia = estrategiaFunc(func(j *Jugador, mesa [][]mazo.Pieza) [][]mazo.Pieza {
+		return [][]mazo.Pieza{mesa[0][:3], mesa[0][3:]}
+	})
+	partida.JugarTurno()
+	if len(partida.Mesa) != 1 || len(partida.Mesa[0]) != 6 || len(ana.Mano) != 15 {
+		t.Errorf("Se esperaba deshacer la reorganización, pero la mesa es %v y Ana tiene %d fichas", partida.Mesa, len(ana.Mano))
+	}
+}
+
+func TestLaAperturaLaDecideElMotor(t *testing.T) {
+	casosDePrueba := []struct {
+		nombre  string
+		juega   estrategiaFunc
+		abierto bool
+		puntos  int // Puntos de JugadorAbrio; -1 si no debe emitirse.
+		fichas  int // Fichas de Ana al acabar el turno.
+	}{
+		{
+			nombre: "Marcar la apertura sin bajar fichas",
+			juega: func(j *Jugador, mesa [][]mazo.Pieza) [][]mazo.Pieza {
+				j.HaHechoPrimeraJugada = true
+				return mesa
+			},
+			puntos: -1,
+			fichas: 18, // 17 + la del robo obligatorio.
+		},
+		{
+			nombre: "Abrir sin marcar la apertura",
+			juega: func(j *Jugador, mesa [][]mazo.Pieza) [][]mazo.Pieza {
+				mesa = append(mesa, j.Mano[len(j.Mano)-3:])
+				j.Mano = j.Mano[:len(j.Mano)-3]
+				return mesa
+			},
+			abierto: true,
+			puntos:  33,
+			fichas:  14,
+		},
+	}
+	for _, tc := range casosDePrueba {
+		t.Run(tc.nombre, func(t *testing.T) {
+			ana := &Jugador{Nombre: "Ana", Estrategia: tc.juega}
+			jugadores := []*Jugador{ana, {Nombre: "Luis", Estrategia: estrategiaRobadora{}}}
+			puntos := -1
+			partida := NuevaPartida(jugadores, OpcionesPartida{
+				Rand:       rand.New(rand.NewSource(1)),
+				Silenciosa: true,
+				Observadores: []Observador{ObservadorFunc(func(_ *Partida, e Evento) {
+					if abrio, ok := e.(JugadorAbrio); ok {
+						puntos = abrio.Puntos
+					}
+				})},
+			})
+			ana.Mano = append(ana.Mano, sacarFichas(partida, fichasDe(t, "R10 R11 R12")...)...)
+			partida.JugarTurno()
+			if ana.HaHechoPrimeraJugada != tc.abierto || puntos != tc.puntos || len(ana.Mano) != tc.fichas {
+				t.Errorf("Se esperaba abierto=%v, %d puntos y %d fichas; se obtuvo abierto=%v, %d puntos y %d fichas",
+					tc.abierto, tc.puntos, tc.fichas, ana.HaHechoPrimeraJugada, puntos, len(ana.Mano))
+			}
+		})
+	}
+}
+
+func TestCerrarConDosComodines(t *testing.T) {
+	casosDePrueba := []struct {
+		nombre   string
+		grupos   reglas.ReglasGrupos
+		ampliado bool
+	}{
+		{nombre: "Estándar: el trío admite una cuarta ficha", ampliado: true},
+		{nombre: "Variante: el trío está cerrado", grupos: reglas.ReglasGrupos{CerrarConDosComodines: true}},
+	}
+	for _, tc := range casosDePrueba {
+		t.Run(tc.nombre, func(t *testing.T) {
+			ana := &Jugador{Nombre: "Ana", HaHechoPrimeraJugada: true}
+			jugadores := []*Jugador{ana, {Nombre: "Luis", Estrategia: estrategiaRobadora{}}}
+			partida := NuevaPartida(jugadores, OpcionesPartida{Rand: rand.New(rand.NewSource(1)), Silenciosa: true, Reglas: ReglasCasa{Grupos: tc.grupos}})
+			partida.Mesa = append(partida.Mesa, sacarFichas(partida, fichasDe(t, "R7 J J")...), sacarFichas(partida, fichasDe(t, "B4 B5 B6 B7")...))
+			// Ana lleva el B7 de la escalera al trío moviendo fichas de la mesa, sin pasar
+			// por SePuedeAnadirFicha.
+			ana.Estrategia = estrategiaFunc(func(j *Jugador, mesa [][]mazo.Pieza) [][]mazo.Pieza {
+				return [][]mazo.Pieza{append(mesa[0], mesa[1][3]), mesa[1][:3]}
+			})
+			partida.JugarTurno()
+			if ampliado := len(partida.Mesa[0]) == 4; ampliado != tc.ampliado {
+				t.Errorf("Se esperaba que el trío se ampliara: %v, pero la mesa es %v", tc.ampliado, partida.Mesa)
+			}
+		})
+	}
+}
+
+func TestCargarPartidaGuardada(t *testing.T) {
+	jugadores := []*Jugador{{Nombre: "Ana", Estrategia: estrategiaRobadora{}}, {Nombre: "Luis", Estrategia: estrategiaRobadora{}}}
+	partida := NuevaPartida(jugadores, OpcionesPartida{Rand: rand.New(rand.NewSource(1)), Silenciosa: true, Reglas: ReglasCasa{SortearInicio: true}})
+	partida.Mesa = append(partida.Mesa, sacarFichas(partida, fichasDe(t, "R1 R2 R3 J")...))
+	for i := 0; i < 3; i++ {
+		partida.JugarTurno()
+	}
+	guardada := partida.Guardada()
+
+	casosDePrueba := []struct {
+		nombre    string
+		cambiar   func(g *PartidaGuardada)
+		jugadores int
+		error     bool
+	}{
+		{nombre: "Partida tal cual", cambiar: func(*PartidaGuardada) {}, jugadores: 2},
+		{nombre: "Otro número de jugadores", cambiar: func(*PartidaGuardada) {}, jugadores: 3, error: true},
+		{nombre: "Una ficha repetida", cambiar: func(g *PartidaGuardada) { g.Jugadores[0].Mano += " " + strings.Fields(g.Pozo)[0] }, jugadores: 2, error: true},
+		{nombre: "Falta una ficha", cambiar: func(g *PartidaGuardada) { g.Pozo = strings.Join(strings.Fields(g.Pozo)[1:], " ") }, jugadores: 2, error: true},
+		{nombre: "Una ficha sin ID", cambiar: func(g *PartidaGuardada) { g.Jugadores[0].Mano += " R1" }, jugadores: 2, error: true},
+		{nombre: "Un ID que no es el de la ficha", cambiar: func(g *PartidaGuardada) { g.Mesa = append([]string{"B" + g.Mesa[0][1:]}, g.Mesa[1:]...) }, jugadores: 2, error: true},
+	}
+	for _, tc := range casosDePrueba {
+		t.Run(tc.nombre, func(t *testing.T) {
+			g := guardada
+			g.Jugadores = append([]JugadorGuardado(nil), guardada.Jugadores...)
+			tc.cambiar(&g)
+			nuevos := make([]*Jugador, tc.jugadores)
+			for i := range nuevos {
+				nuevos[i] = &Jugador{Estrategia: estrategiaRobadora{}}
+			}
+			cargada, err := Cargar(g, nuevos, OpcionesPartida{Silenciosa: true})
+			if (err != nil) != tc.error {
+				t.Fatalf("Se esperaba error: %v, pero fue %v", tc.error, err)
+			}
+			if err == nil && (cargada.HashEstado() != partida.HashEstado() || cargada.JugadorActual().Nombre != partida.JugadorActual().Nombre) {
+				t.Errorf("Se esperaba la misma partida al cargarla, pero es %+v", cargada.Guardada())
+			}
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package motor
+
+import (
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+)
+
+// --- REGISTRO EN JSON LINES ---
+
+// LineaRegistro es cada línea que escribe un Registro: un evento con lo necesario para
+// analizar la partida sin conexión.
+type LineaRegistro struct {
+	Hora    time.Time `json:"hora"`
+	Partida string    `json:"partida"` // Identifica la partida cuando el registro mezcla varias.
+	Turno   int       `json:"turno"`
+	Tipo    string    `json:"tipo"`
+	Evento  Evento    `json:"evento"`
+	Hash    string    `json:"hash"` // Partida.HashEstado justo después del evento.
+}
+
+// Registro escribe los eventos de una o varias partidas en formato JSON Lines, un
+// evento por línea. Se puede compartir entre partidas que se juegan a la vez.
+type Registro struct {
+	Reloj func() time.Time // Para la hora de cada línea; nil usa time.Now.
+
+	mu     sync.Mutex
+	salida io.Writer
+	err    error
+}
+
+// NuevoRegistro crea un registro que escribe en salida.
+func NuevoRegistro(salida io.Writer) *Registro {
+	return &Registro{salida: salida}
+}
+
+// Partida devuelve el observador que registra una partida con el identificador
+// indicado. Hay que pasarlo en OpcionesPartida.Observadores para registrar también el
+// reparto.
+func (r *Registro) Partida(id string) Observador {
+	return ObservadorFunc(func(p *Partida, e Evento) {
+		r.escribir(LineaRegistro{Partida: id, Turno: p.Turno, Tipo: e.Tipo(), Evento: e, Hash: p.HashEstado()})
+	})
+}
+
+// Err devuelve el primer error de escritura. Después de un error no se escribe nada más.
+func (r *Registro) Err() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.err
+}
+
+func (r *Registro) escribir(linea LineaRegistro) {
+	reloj := r.Reloj
+	if reloj == nil {
+		reloj = time.Now
+	}
+	linea.Hora = reloj()
+	datos, err := json.Marshal(linea)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.err != nil {
+		return
+	}
+	if err == nil {
+		_, err = r.salida.Write(append(datos, '\n'))
+	}
+	r.err = err
+}
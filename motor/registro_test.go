@@ -0,0 +1,96 @@
+package motor
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"math/rand"
+	"strings"
+	"testing"
+	"time"
+)
+
+// partidaRegistrada juega una partida entre dos robadores con la semilla indicada y
+// devuelve la partida y su registro.
+func partidaRegistrada(semilla int64) (*Partida, []byte) {
+	var salida bytes.Buffer
+	registro := NuevoRegistro(&salida)
+	registro.Reloj = func() time.Time { return time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC) }
+	jugadores := []*Jugador{{Nombre: "Ana", Estrategia: estrategiaRobadora{}}, {Nombre: "Luis", Estrategia: estrategiaRobadora{}}}
+	partida := NuevaPartida(jugadores, OpcionesPartida{
+		Rand:         rand.New(rand.NewSource(semilla)),
+		Silenciosa:   true,
+		Observadores: []Observador{registro.Partida("7")},
+	})
+	partida.Jugar()
+	return partida, salida.Bytes()
+}
+
+func TestRegistro(t *testing.T) {
+	partida, datos := partidaRegistrada(1)
+
+	var lineas []LineaRegistro
+	scanner := bufio.NewScanner(bytes.NewReader(datos))
+	for scanner.Scan() {
+		// El evento es una interfaz: basta con leer el resto de los campos.
+		var linea struct {
+			LineaRegistro
+			Evento json.RawMessage `json:"evento"`
+		}
+		if err := json.Unmarshal(scanner.Bytes(), &linea); err != nil {
+			t.Fatalf("Se esperaba una línea JSON, pero %q da %v", scanner.Text(), err)
+		}
+		lineas = append(lineas, linea.LineaRegistro)
+	}
+	if len(lineas) < 3 || lineas[0].Tipo != "partida_iniciada" || lineas[len(lineas)-1].Tipo != "partida_terminada" {
+		t.Fatalf("Se esperaba un registro de la partida completa, pero hay %d líneas", len(lineas))
+	}
+	for _, linea := range lineas {
+		if linea.Partida != "7" || linea.Hash == "" || linea.Hora.IsZero() {
+			t.Errorf("Se esperaba partida, hash y hora en cada línea, pero la línea es %+v", linea)
+		}
+	}
+	partida.Turno-- // El final se emite antes de pasar el turno.
+	if ultima := lineas[len(lineas)-1]; ultima.Hash != partida.HashEstado() || ultima.Turno != partida.Turno {
+		t.Errorf("Se esperaba acabar con el estado final (turno %d), pero la última línea es %+v", partida.Turno, ultima)
+	}
+
+	if _, otra := partidaRegistrada(1); !bytes.Equal(datos, otra) {
+		t.Errorf("Se esperaba el mismo registro al repetir la semilla")
+	}
+	if _, otra := partidaRegistrada(2); bytes.Equal(datos, otra) {
+		t.Errorf("Se esperaba un registro distinto con otra semilla")
+	}
+}
+
+func TestHashEstadoDistingueCopias(t *testing.T) {
+	partida, _ := partidaRegistrada(1)
+	// Las dos copias del rojo 1 cambian de sitio: mismas fichas por valor, otro estado.
+	intercambiar := func(texto string) string {
+		fichas := strings.Fields(texto)
+		for i, ficha := range fichas {
+			switch ficha {
+			case "R1#1":
+				fichas[i] = "R1#53"
+			case "R1#53":
+				fichas[i] = "R1#1"
+			}
+		}
+		return strings.Join(fichas, " ")
+	}
+	g := partida.Guardada()
+	for i := range g.Mesa {
+		g.Mesa[i] = intercambiar(g.Mesa[i])
+	}
+	for i := range g.Jugadores {
+		g.Jugadores[i].Mano = intercambiar(g.Jugadores[i].Mano)
+	}
+	g.Pozo = intercambiar(g.Pozo)
+	otra, err := Cargar(g, []*Jugador{{}, {}}, OpcionesPartida{Silenciosa: true})
+	if err != nil {
+		t.Fatalf("No se pudo cargar la partida: %v", err)
+	}
+	if otra.HashEstado() == partida.HashEstado() {
+		t.Errorf("Se esperaba otro hash al intercambiar las dos copias del rojo 1")
+	}
+}
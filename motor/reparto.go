@@ -0,0 +1,56 @@
+package motor
+
+import "com.github/hapkiduki/rummikub/mazo"
+
+// --- REPARTO Y SORTEO DEL PRIMER JUGADOR ---
+
+// FichasPorJugador es lo que recibe cada jugador en el reparto estándar.
+const FichasPorJugador = 14
+
+// repartir da n fichas a cada jugador desde el pozo, de una en una y por orden de
+// asiento, como se reparte en la mesa. Con el mismo pozo el reparto es siempre el
+// mismo. Si el pozo se acaba, los últimos reciben menos.
+func repartir(jugadores []*Jugador, pozo *mazo.Pozo, n int) {
+	for vuelta := 0; vuelta < n; vuelta++ {
+		for _, jugador := range jugadores {
+			jugador.Mano = append(jugador.Mano, pozo.Repartir(1)...)
+		}
+	}
+}
+
+// FichaSorteo es la ficha que un jugador saca en el sorteo del primer jugador.
+type FichaSorteo struct {
+	Jugador string     `json:"jugador"`
+	Ficha   mazo.Pieza `json:"ficha"`
+}
+
+// sortearInicio hace el sorteo oficial: cada jugador saca una ficha del pozo y empieza
+// el que saque el número más alto (el comodín cuenta como 0). Los empatados vuelven a
+// sacar. Al final las fichas vuelven al pozo y se baraja de nuevo. Devuelve el asiento
+// que empieza y todas las fichas sacadas, en orden.
+func sortearInicio(jugadores []*Jugador, pozo *mazo.Pozo, barajar func(n int, swap func(i, j int))) (int, []FichaSorteo) {
+	sacadas := make([]mazo.Pieza, 0, len(jugadores))
+	sorteo := make([]FichaSorteo, 0, len(jugadores))
+	candidatos := make([]int, len(jugadores))
+	for i := range candidatos {
+		candidatos[i] = i
+	}
+	for len(candidatos) > 1 && pozo.Restantes() >= len(candidatos) {
+		mejores := make([]int, 0, len(candidatos))
+		mayor := -1
+		for _, asiento := range candidatos {
+			ficha := pozo.Repartir(1)[0]
+			sacadas = append(sacadas, ficha)
+			sorteo = append(sorteo, FichaSorteo{Jugador: jugadores[asiento].Nombre, Ficha: ficha})
+			switch {
+			case ficha.Numero > mayor:
+				mayor, mejores = ficha.Numero, []int{asiento}
+			case ficha.Numero == mayor:
+				mejores = append(mejores, asiento)
+			}
+		}
+		candidatos = mejores
+	}
+	pozo.Devolver(sacadas, barajar)
+	return candidatos[0], sorteo
+}
@@ -0,0 +1,99 @@
+package motor
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"com.github/hapkiduki/rummikub/mazo"
+)
+
+func TestRepartir(t *testing.T) {
+	fichas := mazo.Completo()[:9]
+	jugadores := []*Jugador{{Nombre: "Ana"}, {Nombre: "Luis"}, {Nombre: "Eva"}}
+	pozo := mazo.NuevoPozo(fichas)
+	repartir(jugadores, pozo, 2)
+
+	for i, jugador := range jugadores {
+		esperada := []mazo.Pieza{fichas[i], fichas[i+3]}
+		if !reflect.DeepEqual(jugador.Mano, esperada) {
+			t.Errorf("Se esperaba que %s recibiera %v, pero recibió %v", jugador.Nombre, esperada, jugador.Mano)
+		}
+	}
+	if pozo.Restantes() != 3 {
+		t.Errorf("Se esperaba que quedaran 3 fichas en el pozo, pero quedan %d", pozo.Restantes())
+	}
+}
+
+func TestRepartoDeNuevaPartida(t *testing.T) {
+	casosDePrueba := []struct {
+		nombre  string
+		reglas  ReglasCasa
+		fichas  int
+		sorteos bool
+	}{
+		{nombre: "Reparto estándar", fichas: FichasPorJugador},
+		{nombre: "Manos de 10 fichas", reglas: ReglasCasa{FichasIniciales: 10}, fichas: 10},
+		{nombre: "Sorteo del primer jugador", reglas: ReglasCasa{SortearInicio: true}, fichas: FichasPorJugador, sorteos: true},
+	}
+
+	for _, tc := range casosDePrueba {
+		t.Run(tc.nombre, func(t *testing.T) {
+			nueva := func() (*Partida, []Evento) {
+				var eventos []Evento
+				jugadores := []*Jugador{{Nombre: "Ana"}, {Nombre: "Luis"}, {Nombre: "Eva"}}
+				partida := NuevaPartida(jugadores, OpcionesPartida{
+					Rand:         rand.New(rand.NewSource(3)),
+					Silenciosa:   true,
+					Reglas:       tc.reglas,
+					Observadores: []Observador{ObservadorFunc(func(_ *Partida, e Evento) { eventos = append(eventos, e) })},
+				})
+				return partida, eventos
+			}
+			partida, eventos := nueva()
+
+			total := partida.Pozo.Restantes()
+			for _, jugador := range partida.Jugadores {
+				if len(jugador.Mano) != tc.fichas {
+					t.Errorf("Se esperaba que %s tuviera %d fichas, pero tiene %d", jugador.Nombre, tc.fichas, len(jugador.Mano))
+				}
+				total += len(jugador.Mano)
+			}
+			if total != len(mazo.Completo()) {
+				t.Errorf("Se esperaba conservar las %d fichas, pero hay %d", len(mazo.Completo()), total)
+			}
+
+			otra, _ := nueva()
+			if !reflect.DeepEqual(partida.Guardada(), otra.Guardada()) {
+				t.Errorf("Se esperaba el mismo reparto con la misma semilla")
+			}
+
+			var sorteo *InicioSorteado
+			for _, e := range eventos {
+				if e, ok := e.(InicioSorteado); ok {
+					sorteo = &e
+				}
+			}
+			if (sorteo != nil) != tc.sorteos {
+				t.Fatalf("Se esperaba sorteo: %v, pero los eventos fueron %v", tc.sorteos, tiposDeEvento(eventos))
+			}
+			if sorteo == nil {
+				return
+			}
+			if partida.JugadorActual().Nombre != sorteo.Jugador {
+				t.Errorf("Se esperaba que empezara %s, pero empieza %s", sorteo.Jugador, partida.JugadorActual().Nombre)
+			}
+			// En la primera ronda nadie saca más que el que acaba empezando.
+			primera := sorteo.Fichas[:len(partida.Jugadores)]
+			mayor := 0
+			for _, f := range primera {
+				mayor = max(mayor, f.Ficha.Numero)
+			}
+			for _, f := range primera {
+				if f.Jugador == sorteo.Jugador && f.Ficha.Numero != mayor {
+					t.Errorf("Se esperaba que empezara quien sacó la ficha más alta, pero el sorteo fue %+v", sorteo.Fichas)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package motor
+
+import (
+	"math/rand"
+	"testing"
+
+	"com.github/hapkiduki/rummikub/mazo"
+)
+
+func TestRoboObligatorio(t *testing.T) {
+	casosDePrueba := []struct {
+		nombre         string
+		reglas         ReglasCasa
+		invalido       bool
+		robosEsperados int
+		motivo         mazo.MotivoRobo
+	}{
+		{nombre: "Quien no juega roba una ficha", robosEsperados: 1, motivo: mazo.RoboObligatorio},
+		{nombre: "Penalización por un movimiento inválido", reglas: ReglasCasa{PenalizacionInvalida: 3}, invalido: true, robosEsperados: 3, motivo: mazo.RoboPenalizacion},
+		{nombre: "Sin penalización el intento inválido no cuenta", invalido: true, robosEsperados: 1, motivo: mazo.RoboObligatorio},
+	}
+
+	for _, tc := range casosDePrueba {
+		t.Run(tc.nombre, func(t *testing.T) {
+			jugadores := []*Jugador{{Nombre: "Ana"}, {Nombre: "Luis", Estrategia: estrategiaRobadora{}}}
+			partida := NuevaPartida(jugadores, OpcionesPartida{Rand: rand.New(rand.NewSource(1)), Silenciosa: true, Reglas: tc.reglas})
+			jugadores[0].Estrategia = estrategiaInvalida{partida: partida, invalido: tc.invalido}
+			partida.JugarTurno()
+
+			historial := partida.Pozo.Historial()
+			if len(historial) != tc.robosEsperados || len(jugadores[0].Mano) != 14+tc.robosEsperados {
+				t.Fatalf("Se esperaban %d robos, pero fueron %d", tc.robosEsperados, len(historial))
+			}
+			for _, robo := range historial {
+				if robo.Motivo != tc.motivo || robo.Jugador != "Ana" {
+					t.Errorf("Se esperaba un robo de Ana por %s, pero fue %+v", tc.motivo, robo)
+				}
+			}
+		})
+	}
+}
+
+func TestRobarHastaPoderJugar(t *testing.T) {
+	jugadores := []*Jugador{{Nombre: "Ana", Estrategia: estrategiaRobadora{}}, {Nombre: "Luis", Estrategia: estrategiaRobadora{}}}
+	partida := NuevaPartida(jugadores, OpcionesPartida{Rand: rand.New(rand.NewSource(1)), Silenciosa: true, Reglas: ReglasCasa{RobarHastaPoderJugar: true}})
+	partida.JugarTurno()
+	if !puedeJugar(partida.Reglas.Grupos, jugadores[0], partida.Mesa) && partida.Pozo.Restantes() > 0 {
+		t.Errorf("Se esperaba que Ana robara hasta poder jugar, pero tiene %v", jugadores[0].Mano)
+	}
+	if robos := len(partida.Pozo.Historial()); robos != len(jugadores[0].Mano)-14 {
+		t.Errorf("Se esperaba un robo por cada ficha nueva, pero hay %d robos", robos)
+	}
+}
+
+// estrategiaInvalida intenta una jugada inválida (si se le pide) y luego pasa.
+type estrategiaInvalida struct {
+	partida  *Partida
+	invalido bool
+}
+
+func (e estrategiaInvalida) JugarTurno(jugador *Jugador, mesa [][]mazo.Pieza) [][]mazo.Pieza {
+	if e.invalido {
+		if _, err := e.partida.Reglas.AplicarMovimiento(jugador, mesa, Movimiento{Accion: MovimientoJugar, Indices: []int{0}}); err != nil && e.partida.IntentoInvalido(jugador) {
+			return mesa
+		}
+	}
+	return mesa
+}
@@ -0,0 +1,73 @@
+package perfiles
+
+import (
+	"com.github/hapkiduki/rummikub/mazo"
+	"com.github/hapkiduki/rummikub/motor"
+	"com.github/hapkiduki/rummikub/reglas"
+)
+
+// --- OBSERVADOR QUE ACTUALIZA UN PERFIL ---
+
+// Observador apunta en el perfil indicado la partida que juega el jugador del asiento
+// dado. rivales es el nombre de la estrategia de cada asiento (el del propio jugador
+// no se usa). Cada estrategia rival cuenta una vez por partida aunque ocupe varios
+// asientos. Hay que crear uno por partida; el perfil se guarda al terminarla.
+func (a *Almacen) Observador(perfil string, asiento int, rivales []string) motor.Observador {
+	turnos := 0
+	var escalera []mazo.Pieza
+	return motor.ObservadorFunc(func(p *motor.Partida, e motor.Evento) {
+		jugador := p.Jugadores[asiento].Nombre
+		switch e := e.(type) {
+		case motor.TurnoIniciado:
+			if e.Jugador == jugador {
+				turnos++
+			}
+		case motor.JugadaBajada:
+			if e.Jugador == jugador {
+				escalera = escaleraMasLarga(escalera, p.Mesa[e.Jugada])
+			}
+		case motor.FichaAnadida:
+			if e.Jugador == jugador {
+				escalera = escaleraMasLarga(escalera, p.Mesa[e.Jugada])
+			}
+		case motor.PartidaTerminada:
+			a.actualizar(perfil, func(pf *Perfil) {
+				gana := e.Ganador == jugador
+				pf.Partidas++
+				pf.PuntosEnMano += e.Jugadores[asiento].Puntos
+				if gana {
+					pf.Victorias++
+					pf.apuntarVictoria(turnos)
+				}
+				contados := make(map[string]bool, len(rivales))
+				for i, rival := range rivales {
+					if i == asiento || i >= len(e.Jugadores) || contados[rival] {
+						continue
+					}
+					contados[rival] = true
+					contra, ok := pf.Rivales[rival]
+					if !ok {
+						contra = &ContraRival{}
+						pf.Rivales[rival] = contra
+					}
+					contra.Partidas++
+					if gana {
+						contra.Victorias++
+					}
+				}
+				if len(escalera) > len(pf.EscaleraMasLarga) {
+					pf.EscaleraMasLarga = escalera
+				}
+			})
+		}
+	})
+}
+
+// escaleraMasLarga devuelve una copia de jugada si es una escalera más larga que la
+// que ya se tenía.
+func escaleraMasLarga(actual, jugada []mazo.Pieza) []mazo.Pieza {
+	if len(jugada) <= len(actual) || !reglas.EsEscaleraValida(jugada) {
+		return actual
+	}
+	return append([]mazo.Pieza(nil), jugada...)
+}
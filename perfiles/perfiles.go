@@ -0,0 +1,163 @@
+// Package perfiles guarda las estadísticas de cada jugador entre sesiones en un
+// archivo JSON local. Un observador de la partida las actualiza al acabar cada
+// partida, así que no dependen de la interfaz con la que se juegue.
+package perfiles
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"com.github/hapkiduki/rummikub/mazo"
+)
+
+// victoriasGuardadas es cuántas de las victorias más rápidas recuerda cada perfil.
+const victoriasGuardadas = 5
+
+// Perfil son las estadísticas acumuladas de un jugador.
+type Perfil struct {
+	Nombre       string `json:"nombre"`
+	Partidas     int    `json:"partidas"`
+	Victorias    int    `json:"victorias"`
+	PuntosEnMano int    `json:"puntos_en_mano"` // Suma de lo que le quedó en la mano al acabar cada partida.
+	// Rivales cuenta las partidas y victorias contra cada estrategia rival, por nombre.
+	Rivales map[string]*ContraRival `json:"rivales"`
+	// VictoriasMasRapidas son los turnos propios de sus victorias más cortas, de menos a más.
+	VictoriasMasRapidas []int        `json:"victorias_mas_rapidas"`
+	EscaleraMasLarga    []mazo.Pieza `json:"escalera_mas_larga"` // La más larga que ha dejado en la mesa.
+}
+
+// ContraRival es el balance de un perfil contra una estrategia.
+type ContraRival struct {
+	Partidas  int `json:"partidas"`
+	Victorias int `json:"victorias"`
+}
+
+// MediaPuntosEnMano es lo que le queda de media en la mano al acabar una partida.
+func (p *Perfil) MediaPuntosEnMano() float64 {
+	if p.Partidas == 0 {
+		return 0
+	}
+	return float64(p.PuntosEnMano) / float64(p.Partidas)
+}
+
+// apuntarVictoria guarda los turnos de una victoria si está entre las más rápidas.
+func (p *Perfil) apuntarVictoria(turnos int) {
+	p.VictoriasMasRapidas = append(p.VictoriasMasRapidas, turnos)
+	sort.Ints(p.VictoriasMasRapidas)
+	if len(p.VictoriasMasRapidas) > victoriasGuardadas {
+		p.VictoriasMasRapidas = p.VictoriasMasRapidas[:victoriasGuardadas]
+	}
+}
+
+// --- ALMACÉN EN UN ARCHIVO JSON ---
+
+// Almacen son todos los perfiles de un archivo. Se puede compartir entre partidas que
+// se juegan a la vez.
+type Almacen struct {
+	Ruta string
+
+	mu       sync.Mutex
+	perfiles map[string]*Perfil
+	err      error
+}
+
+// RutaPorDefecto es el archivo de perfiles en la carpeta de configuración del usuario.
+func RutaPorDefecto() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "rummikub", "perfiles.json"), nil
+}
+
+// Abrir lee los perfiles del archivo. Si el archivo no existe, el almacén empieza
+// vacío y se crea al guardar.
+func Abrir(ruta string) (*Almacen, error) {
+	a := &Almacen{Ruta: ruta, perfiles: make(map[string]*Perfil)}
+	datos, err := os.ReadFile(ruta)
+	if errors.Is(err, fs.ErrNotExist) {
+		return a, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var perfiles []*Perfil
+	if err := json.Unmarshal(datos, &perfiles); err != nil {
+		return nil, err
+	}
+	for _, p := range perfiles {
+		a.perfiles[p.Nombre] = p
+	}
+	return a, nil
+}
+
+// Perfiles devuelve una copia de los perfiles, por orden alfabético.
+func (a *Almacen) Perfiles() []Perfil {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	perfiles := make([]Perfil, 0, len(a.perfiles))
+	for _, p := range a.perfiles {
+		perfiles = append(perfiles, *p)
+	}
+	sort.Slice(perfiles, func(i, j int) bool { return perfiles[i].Nombre < perfiles[j].Nombre })
+	return perfiles
+}
+
+// Guardar escribe todos los perfiles en el archivo. Primero escribe un archivo
+// temporal y luego lo renombra, para no dejar el archivo a medias.
+func (a *Almacen) Guardar() error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.guardar()
+}
+
+// Err devuelve el primer error al guardar después de una partida.
+func (a *Almacen) Err() error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.err
+}
+
+// actualizar aplica cambios al perfil con ese nombre, creándolo si hace falta, y
+// guarda el archivo. Los errores se guardan para Err.
+func (a *Almacen) actualizar(nombre string, cambios func(p *Perfil)) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	p, ok := a.perfiles[nombre]
+	if !ok {
+		p = &Perfil{Nombre: nombre}
+		a.perfiles[nombre] = p
+	}
+	if p.Rivales == nil {
+		p.Rivales = make(map[string]*ContraRival)
+	}
+	cambios(p)
+	if err := a.guardar(); err != nil && a.err == nil {
+		a.err = err
+	}
+}
+
+func (a *Almacen) guardar() error {
+	perfiles := make([]*Perfil, 0, len(a.perfiles))
+	for _, p := range a.perfiles {
+		perfiles = append(perfiles, p)
+	}
+	sort.Slice(perfiles, func(i, j int) bool { return perfiles[i].Nombre < perfiles[j].Nombre })
+	datos, err := json.MarshalIndent(perfiles, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(a.Ruta), 0o755); err != nil {
+		return err
+	}
+	temporal := a.Ruta + ".tmp"
+	if err := os.WriteFile(temporal, append(datos, '\n'), 0o644); err != nil {
+		return err
+	}
+	return os.Rename(temporal, a.Ruta)
+}
@@ -0,0 +1,78 @@
+package perfiles
+
+import (
+	"math/rand"
+	"path/filepath"
+	"testing"
+
+	"com.github/hapkiduki/rummikub/bots"
+	"com.github/hapkiduki/rummikub/motor"
+)
+
+func TestObservadorGuardaElPerfil(t *testing.T) {
+	ruta := filepath.Join(t.TempDir(), "perfiles", "perfiles.json")
+	almacen, err := Abrir(ruta)
+	if err != nil {
+		t.Fatalf("Se esperaba un almacén vacío, pero fue %v", err)
+	}
+	var resultado motor.PartidaTerminada
+	for semilla := int64(1); semilla <= 3; semilla++ {
+		jugadores := []*motor.Jugador{
+			{Nombre: "Ana", Estrategia: bots.EstrategiaIntermedio{Silencioso: true}},
+			{Nombre: "Bot", Estrategia: bots.EstrategiaNovato{Silencioso: true}},
+			{Nombre: "Bot 2", Estrategia: bots.EstrategiaNovato{Silencioso: true}},
+		}
+		partida := motor.NuevaPartida(jugadores, motor.OpcionesPartida{
+			Rand:       rand.New(rand.NewSource(semilla)),
+			Silenciosa: true,
+			Observadores: []motor.Observador{
+				almacen.Observador("ana", 0, []string{"humano", "novato", "novato"}),
+				motor.ObservadorFunc(func(_ *motor.Partida, e motor.Evento) {
+					if fin, ok := e.(motor.PartidaTerminada); ok {
+						resultado = fin
+					}
+				}),
+			},
+		})
+		partida.Jugar()
+	}
+	if err := almacen.Err(); err != nil {
+		t.Fatalf("Se esperaba guardar sin errores, pero fue %v", err)
+	}
+
+	leido, err := Abrir(ruta)
+	if err != nil {
+		t.Fatalf("Se esperaba leer el archivo guardado, pero fue %v", err)
+	}
+	perfiles := leido.Perfiles()
+	if len(perfiles) != 1 || perfiles[0].Nombre != "ana" {
+		t.Fatalf("Se esperaba solo el perfil de ana, pero fue %+v", perfiles)
+	}
+	p := perfiles[0]
+	// Los dos novatos cuentan como un solo rival en cada partida.
+	if p.Partidas != 3 || p.Rivales["novato"] == nil || p.Rivales["novato"].Partidas != 3 || p.Rivales["novato"].Victorias != p.Victorias {
+		t.Errorf("Se esperaban 3 partidas contra novato, pero el perfil es %+v", p)
+	}
+	if p.Rivales["humano"] != nil {
+		t.Errorf("Se esperaba no contar al propio jugador como rival, pero el perfil es %+v", p)
+	}
+	if len(p.VictoriasMasRapidas) != p.Victorias || p.PuntosEnMano < resultado.Jugadores[0].Puntos {
+		t.Errorf("Se esperaba una victoria rápida por victoria y los puntos sumados, pero el perfil es %+v", p)
+	}
+	if p.Victorias > 0 && p.VictoriasMasRapidas[0] == 0 {
+		t.Errorf("Se esperaban turnos en las victorias, pero el perfil es %+v", p)
+	}
+}
+
+func TestApuntarVictoria(t *testing.T) {
+	var p Perfil
+	for _, turnos := range []int{12, 9, 30, 15, 9, 20, 7} {
+		p.apuntarVictoria(turnos)
+	}
+	esperadas := []int{7, 9, 9, 12, 15}
+	for i, turnos := range esperadas {
+		if i >= len(p.VictoriasMasRapidas) || p.VictoriasMasRapidas[i] != turnos {
+			t.Fatalf("Se esperaban las victorias %v, pero fueron %v", esperadas, p.VictoriasMasRapidas)
+		}
+	}
+}
@@ -0,0 +1,213 @@
+package reglas
+
+import "com.github/hapkiduki/rummikub/mazo"
+
+// --- BÚSQUEDA DE JUGADAS EN LA MANO ---
+
+// colores son los colores de las fichas normales, en el orden en que se recorren.
+var colores = []int{mazo.Rojo, mazo.Azul, mazo.Amarillo, mazo.Negro}
+
+// ResultadoBusqueda es la jugada que encuentra BuscarJugadaEnMano, con las fichas de
+// la mano que la forman. Jugada es nil si no hay ninguna.
+type ResultadoBusqueda struct {
+	Jugada []mazo.Pieza
+}
+
+// QuitarFichasDeMano devuelve una mano nueva sin las fichas de los índices indicados.
+func QuitarFichasDeMano(mano []mazo.Pieza, indicesARemover map[int]bool) []mazo.Pieza {
+	nuevaMano := make([]mazo.Pieza, 0)
+	for i, ficha := range mano {
+		if !indicesARemover[i] {
+			nuevaMano = append(nuevaMano, ficha)
+		}
+	}
+	return nuevaMano
+}
+
+// BuscarJugadaEnMano busca la escalera o el grupo más largo de la mano, sin usar
+// comodines, y manda el resultado por el canal devuelto. Las fichas se quitan luego
+// de la mano con QuitarFichas.
+func BuscarJugadaEnMano(mano []mazo.Pieza) <-chan ResultadoBusqueda {
+	ch := make(chan ResultadoBusqueda, 1)
+	go func() {
+		defer close(ch)
+		if len(mano) < 3 {
+			ch <- ResultadoBusqueda{}
+			return
+		}
+		conjunto := mazo.NuevoConjunto(mano)
+		var mejor []mazo.Pieza
+
+		// Escaleras: las fichas de cada color por número, sin repetir ninguno.
+		for _, color := range colores {
+			fichas := make([]mazo.Pieza, 0, 13)
+			for numero := 1; numero <= 13; numero++ {
+				if ficha := (mazo.Pieza{Color: color, Numero: numero}); conjunto.Contiene(ficha) {
+					fichas = append(fichas, ficha)
+				}
+			}
+			if escalera := findLongestRun(fichas); len(escalera) > len(mejor) {
+				mejor = escalera
+			}
+		}
+
+		// Tríos y cuartetas: una ficha de cada color con el mismo número. Recorremos
+		// los números en orden para que la búsqueda sea determinista.
+		for numero := 1; numero <= 13; numero++ {
+			grupo := make([]mazo.Pieza, 0, 4)
+			for _, color := range colores {
+				if ficha := (mazo.Pieza{Color: color, Numero: numero}); conjunto.Contiene(ficha) {
+					grupo = append(grupo, ficha)
+				}
+			}
+			if len(grupo) >= 3 && len(grupo) > len(mejor) {
+				mejor = grupo
+			}
+		}
+		if mejor == nil {
+			ch <- ResultadoBusqueda{}
+			return
+		}
+
+		// La jugada se forma con las fichas de la mano, no con las copias sin ID del conjunto.
+		jugada := make([]mazo.Pieza, 0, len(mejor))
+		for _, i := range IndicesEnMano(mano, mejor) {
+			jugada = append(jugada, mano[i])
+		}
+		OrdenarJugada(jugada)
+		ch <- ResultadoBusqueda{Jugada: jugada}
+	}()
+	return ch
+}
+
+func findLongestRun(group []mazo.Pieza) []mazo.Pieza {
+	if len(group) < 3 {
+		return nil
+	}
+	var longest []mazo.Pieza
+	current := []mazo.Pieza{group[0]}
+	for i := 1; i < len(group); i++ {
+		if group[i].Numero == group[i-1].Numero+1 {
+			current = append(current, group[i])
+		} else {
+			if len(current) >= 3 && len(current) > len(longest) {
+				longest = current
+			}
+			current = []mazo.Pieza{group[i]}
+		}
+	}
+	if len(current) >= 3 && len(current) > len(longest) {
+		longest = current
+	}
+	return longest
+}
+
+// JugadasPosibles enumera tríos, cuartetas y escaleras que se pueden formar con la mano,
+// usando comodines para completar huecos. A diferencia de BuscarJugadaEnMano, tiene en
+// cuenta los comodines y devuelve todas las candidatas, no solo la más larga. Las
+// candidatas pueden compartir fichas. Aplica el reglamento estándar.
+func JugadasPosibles(mano []mazo.Pieza) [][]mazo.Pieza {
+	return ReglasGrupos{}.JugadasPosibles(mano)
+}
+
+// JugadasPosibles es como la función JugadasPosibles, pero solo devuelve las
+// candidatas que valen con las variantes de r.
+func (r ReglasGrupos) JugadasPosibles(mano []mazo.Pieza) [][]mazo.Pieza {
+	conjunto := mazo.NuevoConjunto(mano)
+	comodines := make([]mazo.Pieza, conjunto.Comodines())
+	for i := range comodines {
+		comodines[i] = mazo.Pieza{Color: -1, Numero: 0}
+	}
+	jugadas := make([][]mazo.Pieza, 0)
+	agregar := func(jugada []mazo.Pieza) {
+		if r.EsJugadaValida(jugada) {
+			jugadas = append(jugadas, append([]mazo.Pieza(nil), jugada...))
+		}
+	}
+
+	// Tríos y cuartetas: una ficha de cada color con el mismo número.
+	for numero := 1; numero <= 13; numero++ {
+		grupo := make([]mazo.Pieza, 0, 4)
+		for _, color := range colores {
+			if ficha := (mazo.Pieza{Color: color, Numero: numero}); conjunto.Contiene(ficha) {
+				grupo = append(grupo, ficha)
+			}
+		}
+		if len(grupo) == 0 {
+			continue
+		}
+		usados := 0
+		for len(grupo) < 3 && usados < len(comodines) {
+			grupo = append(grupo, comodines[usados])
+			usados++
+		}
+		agregar(grupo)
+		// De una cuarteta salen también cuatro tríos, que dejan libre una ficha para
+		// otra jugada.
+		if len(grupo) == 4 {
+			for sobra := range grupo {
+				agregar(append(append([]mazo.Pieza(nil), grupo[:sobra]...), grupo[sobra+1:]...))
+			}
+		}
+	}
+
+	// Escaleras: desde cada número, avanzamos mientras haya ficha o comodín. Los
+	// comodines por debajo de la primera ficha solo hacen falta en las escaleras que
+	// llegan al 13, como J R12 R13; las demás ya salen con el comodín al final.
+	for _, color := range colores {
+		for inicio := 1; inicio <= 13; inicio++ {
+			if !conjunto.Contiene(mazo.Pieza{Color: color, Numero: inicio}) {
+				continue
+			}
+			for antes := 0; antes <= len(comodines) && antes < inicio; antes++ {
+				escalera := make([]mazo.Pieza, 0, 13)
+				escalera = append(escalera, comodines[:antes]...)
+				usados := antes
+				for numero := inicio; numero <= 13; numero++ {
+					if ficha := (mazo.Pieza{Color: color, Numero: numero}); conjunto.Contiene(ficha) {
+						escalera = append(escalera, ficha)
+					} else if usados < len(comodines) {
+						escalera = append(escalera, comodines[usados])
+						usados++
+					} else {
+						break
+					}
+					if len(escalera) >= 3 && (antes == 0 || numero == 13) {
+						agregar(escalera)
+					}
+				}
+			}
+		}
+	}
+	return jugadas
+}
+
+// MejorJugada devuelve la candidata con más valor (si valor es true) o con más fichas.
+func MejorJugada(jugadas [][]mazo.Pieza, porValor bool) []mazo.Pieza {
+	var mejor []mazo.Pieza
+	mejorPuntos := -1
+	for _, jugada := range jugadas {
+		puntos := len(jugada)
+		if porValor {
+			puntos = CalcularValorJugada(jugada)
+		}
+		if puntos > mejorPuntos {
+			mejor, mejorPuntos = jugada, puntos
+		}
+	}
+	return mejor
+}
+
+// IndicesEnMano busca en la mano los índices de las fichas indicadas, sin repetir
+// índice. Las fichas se cuentan en un ConjuntoFichas, así que las dos copias de una
+// ficha son intercambiables: se elige la primera de la mano que no se haya usado.
+func IndicesEnMano(mano []mazo.Pieza, fichas []mazo.Pieza) []int {
+	pendientes := mazo.NuevoConjunto(fichas)
+	indices := make([]int, 0, len(fichas))
+	for i, p := range mano {
+		if pendientes.Quitar(p) {
+			indices = append(indices, i)
+		}
+	}
+	return indices
+}
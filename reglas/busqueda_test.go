@@ -0,0 +1,71 @@
+package reglas
+
+import (
+	"reflect"
+	"testing"
+
+	"com.github/hapkiduki/rummikub/mazo"
+)
+
+func TestIndicesEnMano(t *testing.T) {
+	completo := mazo.Completo()
+	copia1, copia2 := completo[0], completo[52]
+
+	// Las dos copias de una ficha son intercambiables, pero no se repite índice.
+	mano := []mazo.Pieza{copia1, {Color: mazo.Azul, Numero: 4}, copia2}
+	if indices := IndicesEnMano(mano, []mazo.Pieza{copia2}); !reflect.DeepEqual(indices, []int{0}) {
+		t.Errorf("Se esperaba el índice [0], pero fue %v", indices)
+	}
+	if indices := IndicesEnMano(mano, []mazo.Pieza{{Color: mazo.Rojo, Numero: 1}, {Color: mazo.Rojo, Numero: 1}}); !reflect.DeepEqual(indices, []int{0, 2}) {
+		t.Errorf("Se esperaban los índices [0 2] al pedir las dos copias, pero fue %v", indices)
+	}
+	if indices := IndicesEnMano(mano, []mazo.Pieza{{Color: mazo.Negro, Numero: 9}}); len(indices) != 0 {
+		t.Errorf("Se esperaba que no encontrara una ficha que no está en la mano, pero fue %v", indices)
+	}
+}
+
+func TestBuscarJugadaEnMano(t *testing.T) {
+	completo := mazo.Completo()
+	// R4, R5, las dos copias de R5, R6 y B9: el R5 repetido no corta la escalera.
+	mano := []mazo.Pieza{completo[3], completo[4], completo[56], completo[5], completo[21]}
+	result := <-BuscarJugadaEnMano(mano)
+	if !reflect.DeepEqual(result.Jugada, []mazo.Pieza{completo[3], completo[4], completo[5]}) {
+		t.Fatalf("Se esperaba la escalera R4 R5 R6 con las fichas de la mano, pero fue %v", result.Jugada)
+	}
+	if resto := QuitarFichas(mano, result.Jugada); !reflect.DeepEqual(resto, []mazo.Pieza{completo[56], completo[21]}) {
+		t.Errorf("Se esperaba que quedaran la otra copia de R5 y B9, pero quedaron %v", resto)
+	}
+}
+
+func TestJugadasPosibles(t *testing.T) {
+	casosDePrueba := []struct {
+		nombre    string
+		mano      string
+		contiene  string
+		candidata bool
+	}{
+		{nombre: "Comodín al final de la escalera", mano: "R5 R6 J", contiene: "R5 R6 J", candidata: true},
+		{nombre: "Comodín debajo de R12 R13", mano: "R12 R13 J", contiene: "J R12 R13", candidata: true},
+		{nombre: "Dos comodines debajo de R13", mano: "J R13 J", contiene: "J J R13", candidata: true},
+		{nombre: "Comodín debajo y en un hueco hasta el 13", mano: "R11 R13 J J", contiene: "J R11 J R13", candidata: true},
+		{nombre: "Sin repetir con el comodín debajo lo que ya sale al final", mano: "R5 R6 J", contiene: "J R5 R6", candidata: false},
+	}
+
+	for _, tc := range casosDePrueba {
+		t.Run(tc.nombre, func(t *testing.T) {
+			mano, err := mazo.ParsearFichas(tc.mano)
+			if err != nil {
+				t.Fatalf("Notación inválida en el caso: %v", err)
+			}
+			encontrada := false
+			for _, jugada := range JugadasPosibles(mano) {
+				if mazo.NotacionFichas(jugada) == tc.contiene {
+					encontrada = true
+				}
+			}
+			if encontrada != tc.candidata {
+				t.Errorf("Se esperaba que %s fuera candidata: %t, pero fue %t", tc.contiene, tc.candidata, encontrada)
+			}
+		})
+	}
+}
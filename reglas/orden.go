@@ -0,0 +1,117 @@
+package reglas
+
+import (
+	"sort"
+
+	"com.github/hapkiduki/rummikub/idioma"
+	"com.github/hapkiduki/rummikub/mazo"
+)
+
+// --- ORDEN DE LA MANO ---
+
+// ModoOrden indica cómo se muestra la mano del jugador humano.
+type ModoOrden int
+
+const (
+	OrdenPorColor  ModoOrden = iota // Por color y número: deja a la vista las escaleras.
+	OrdenPorNumero                  // Por número y color: deja a la vista los tríos.
+	OrdenSugerido                   // Las jugadas que encuentra el bot, juntas, y el resto por color.
+)
+
+// String devuelve el nombre del modo en el idioma activo.
+func (m ModoOrden) String() string {
+	switch m {
+	case OrdenPorNumero:
+		return idioma.T("por número")
+	case OrdenSugerido:
+		return idioma.T("jugadas sugeridas")
+	default:
+		return idioma.T("por color")
+	}
+}
+
+// Siguiente devuelve el modo que sigue a m, para alternar entre todos ellos.
+func (m ModoOrden) Siguiente() ModoOrden {
+	return (m + 1) % 3
+}
+
+// agruparMano reparte la mano en grupos según el modo: un grupo por color, uno por
+// número o uno por jugada sugerida. Los comodines sueltos van en un último grupo.
+func agruparMano(mano []mazo.Pieza, modo ModoOrden) [][]mazo.Pieza {
+	grupos := make([][]mazo.Pieza, 0)
+	restantes := append([]mazo.Pieza(nil), mano...)
+	if modo == OrdenSugerido {
+		for {
+			result := <-BuscarJugadaEnMano(restantes)
+			if result.Jugada == nil {
+				break
+			}
+			jugada := append([]mazo.Pieza(nil), result.Jugada...)
+			OrdenarJugada(jugada)
+			grupos = append(grupos, jugada)
+			restantes = QuitarFichas(restantes, jugada)
+		}
+		modo = OrdenPorColor
+	}
+	clave := func(p mazo.Pieza) int {
+		if modo == OrdenPorNumero {
+			return p.Numero
+		}
+		return p.Color
+	}
+	sort.SliceStable(restantes, func(i, j int) bool {
+		// Los comodines siempre al final.
+		if (restantes[i].Numero == 0) != (restantes[j].Numero == 0) {
+			return restantes[j].Numero == 0
+		}
+		if clave(restantes[i]) != clave(restantes[j]) {
+			return clave(restantes[i]) < clave(restantes[j])
+		}
+		if restantes[i].Numero != restantes[j].Numero {
+			return restantes[i].Numero < restantes[j].Numero
+		}
+		return restantes[i].Color < restantes[j].Color
+	})
+	for i, p := range restantes {
+		mismoGrupo := i > 0 && (p.Numero == 0) == (restantes[i-1].Numero == 0) &&
+			(p.Numero == 0 || clave(p) == clave(restantes[i-1]))
+		if mismoGrupo {
+			grupos[len(grupos)-1] = append(grupos[len(grupos)-1], p)
+		} else {
+			grupos = append(grupos, []mazo.Pieza{p})
+		}
+	}
+	return grupos
+}
+
+// OrdenarMano reordena la mano en el sitio según el modo indicado y devuelve los
+// índices de cada grupo en la mano ya ordenada.
+func OrdenarMano(mano []mazo.Pieza, modo ModoOrden) [][]int {
+	ordenada := make([]mazo.Pieza, 0, len(mano))
+	indices := make([][]int, 0)
+	for _, grupo := range agruparMano(mano, modo) {
+		fila := make([]int, len(grupo))
+		for i := range grupo {
+			fila[i] = len(ordenada) + i
+		}
+		indices = append(indices, fila)
+		ordenada = append(ordenada, grupo...)
+	}
+	copy(mano, ordenada)
+	return indices
+}
+
+// QuitarFichas quita de la mano cada ficha indicada. Se compara también el ID, así que
+// se quita justo esa copia y no la otra ficha igual.
+func QuitarFichas(mano []mazo.Pieza, fichas []mazo.Pieza) []mazo.Pieza {
+	nuevaMano := append([]mazo.Pieza(nil), mano...)
+	for _, ficha := range fichas {
+		for i, p := range nuevaMano {
+			if p == ficha {
+				nuevaMano = append(nuevaMano[:i], nuevaMano[i+1:]...)
+				break
+			}
+		}
+	}
+	return nuevaMano
+}
@@ -0,0 +1,67 @@
+package reglas
+
+import (
+	"reflect"
+	"testing"
+
+	"com.github/hapkiduki/rummikub/mazo"
+)
+
+func TestOrdenarMano(t *testing.T) {
+	comodin := mazo.Pieza{Color: -1, Numero: 0}
+	mano := []mazo.Pieza{
+		{Color: mazo.Azul, Numero: 2},
+		comodin,
+		{Color: mazo.Rojo, Numero: 9},
+		{Color: mazo.Rojo, Numero: 10},
+		{Color: mazo.Azul, Numero: 9},
+		{Color: mazo.Rojo, Numero: 11},
+	}
+	casosDePrueba := []struct {
+		nombre   string
+		modo     ModoOrden
+		esperado []mazo.Pieza
+		grupos   int
+	}{
+		{
+			nombre: "Por color con el comodín al final",
+			modo:   OrdenPorColor,
+			esperado: []mazo.Pieza{
+				{Color: mazo.Rojo, Numero: 9}, {Color: mazo.Rojo, Numero: 10}, {Color: mazo.Rojo, Numero: 11},
+				{Color: mazo.Azul, Numero: 2}, {Color: mazo.Azul, Numero: 9}, comodin,
+			},
+			grupos: 3,
+		},
+		{
+			nombre: "Por número",
+			modo:   OrdenPorNumero,
+			esperado: []mazo.Pieza{
+				{Color: mazo.Azul, Numero: 2}, {Color: mazo.Rojo, Numero: 9}, {Color: mazo.Azul, Numero: 9},
+				{Color: mazo.Rojo, Numero: 10}, {Color: mazo.Rojo, Numero: 11}, comodin,
+			},
+			grupos: 5,
+		},
+		{
+			nombre: "Jugada sugerida primero",
+			modo:   OrdenSugerido,
+			esperado: []mazo.Pieza{
+				{Color: mazo.Rojo, Numero: 9}, {Color: mazo.Rojo, Numero: 10}, {Color: mazo.Rojo, Numero: 11},
+				{Color: mazo.Azul, Numero: 2}, {Color: mazo.Azul, Numero: 9}, comodin,
+			},
+			grupos: 3,
+		},
+	}
+
+	for _, tc := range casosDePrueba {
+		t.Run(tc.nombre, func(t *testing.T) {
+			copia := append([]mazo.Pieza(nil), mano...)
+			grupos := OrdenarMano(copia, tc.modo)
+			if !reflect.DeepEqual(copia, tc.esperado) {
+				t.Errorf("Se esperaba %v, pero se obtuvo %v", tc.esperado, copia)
+			}
+			if len(grupos) != tc.grupos {
+				t.Errorf("Se esperaban %d grupos, pero se obtuvieron %d", tc.grupos, len(grupos))
+			}
+		})
+	}
+}
@@ -0,0 +1,205 @@
+// Package reglas contiene el reglamento del Rummikub: qué es una jugada válida, cuánto
+// vale y cómo se busca en una mano.
+package reglas
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"com.github/hapkiduki/rummikub/idioma"
+	"com.github/hapkiduki/rummikub/mazo"
+)
+
+// ReglasGrupos son las variantes del reglamento para tríos y cuartetas.
+// El valor cero es el reglamento estándar, el que aplican las funciones del paquete;
+// los métodos de ReglasGrupos aplican las variantes de la partida.
+type ReglasGrupos struct {
+	// LimitarComodines prohíbe los grupos con más comodines que fichas normales,
+	// como un 7 con dos comodines.
+	LimitarComodines bool
+	// PermitirSoloComodines acepta grupos formados solo por comodines.
+	PermitirSoloComodines bool
+	// CerrarConDosComodines impide añadir una cuarta ficha a un trío con dos comodines.
+	CerrarConDosComodines bool
+}
+
+// ParsearGrupos convierte el valor del flag -grupos, una lista separada por
+// comas, en ReglasGrupos. La cadena vacía es el reglamento estándar.
+func ParsearGrupos(s string) (ReglasGrupos, error) {
+	var r ReglasGrupos
+	for _, variante := range strings.Split(s, ",") {
+		switch strings.ToLower(strings.TrimSpace(variante)) {
+		case "":
+		case "limitar-comodines":
+			r.LimitarComodines = true
+		case "solo-comodines":
+			r.PermitirSoloComodines = true
+		case "cerrar-dos-comodines":
+			r.CerrarConDosComodines = true
+		default:
+			return ReglasGrupos{}, fmt.Errorf(idioma.T("variante de grupos desconocida '%s' (usa limitar-comodines, solo-comodines o cerrar-dos-comodines)"), variante)
+		}
+	}
+	return r, nil
+}
+
+// EsTrioValido comprueba si un conjunto de fichas es una tercia o cuarteta válida
+// con el reglamento estándar.
+func EsTrioValido(fichas []mazo.Pieza) bool {
+	return ReglasGrupos{}.EsTrioValido(fichas)
+}
+
+// EsTrioValido comprueba si un conjunto de fichas es una tercia o cuarteta válida.
+// validarGrupo explica el motivo cuando no lo es.
+func (r ReglasGrupos) EsTrioValido(fichas []mazo.Pieza) bool {
+	normales, conjunto, inexistentes := contarFichas(fichas)
+	return len(inexistentes) == 0 && r.validarGrupo(normales, conjunto) == nil
+}
+
+// SePuedeAmpliarGrupo aplica CerrarConDosComodines: un trío con dos comodines no
+// admite más fichas. Las escaleras no se ven afectadas.
+func (r ReglasGrupos) SePuedeAmpliarGrupo(jugada []mazo.Pieza) bool {
+	if !r.CerrarConDosComodines || !r.EsTrioValido(append([]mazo.Pieza(nil), jugada...)) {
+		return true
+	}
+	return mazo.NuevoConjunto(jugada).Comodines() < 2
+}
+
+// EsEscaleraValida comprueba si un conjunto de fichas es una escalera válida.
+// validarEscalera explica el motivo cuando no lo es.
+func EsEscaleraValida(fichas []mazo.Pieza) bool {
+	normales, conjunto, inexistentes := contarFichas(fichas)
+	return len(inexistentes) == 0 && validarEscalera(normales, conjunto) == nil
+}
+
+// EsJugadaValida determina si una jugada es válida, ya sea una tercia/cuarteta o una escalera.
+// ValidarJugada explica el motivo cuando no lo es.
+func EsJugadaValida(fichas []mazo.Pieza) bool {
+	return ReglasGrupos{}.EsJugadaValida(fichas)
+}
+
+// EsJugadaValida es como la función EsJugadaValida, con las variantes de r.
+func (r ReglasGrupos) EsJugadaValida(fichas []mazo.Pieza) bool {
+	return r.ValidarJugada(fichas) == nil
+}
+
+// CalcularValorJugada suma los números de las fichas en una jugada.
+// Los comodines toman el valor de la ficha que reemplazan.
+func CalcularValorJugada(jugada []mazo.Pieza) int {
+	// (Esta es una implementación simple, la lógica del comodín se puede refinar)
+	// Por ahora, asumimos que el comodín ya fue validado y toma el valor correcto.
+	// Primero, necesitamos saber qué valor debe tener el comodín.
+	// Haremos una copia para no alterar la jugada original.
+	copia := make([]mazo.Pieza, len(jugada))
+	copy(copia, jugada)
+	// Las variantes de grupos solo deciden qué tríos valen, no cuánto: con la más
+	// permisiva se puntúa cualquier trío que haya aceptado la partida.
+	if (ReglasGrupos{PermitirSoloComodines: true}).EsTrioValido(copia) {
+		valor := 0
+		for _, f := range copia {
+			if f.Numero != 0 {
+				valor = f.Numero // Encontramos el número del trío
+				break
+			}
+		}
+		return valor * len(copia)
+	}
+	// En una escalera los comodines cubren primero los huecos y luego la alargan hacia
+	// arriba; solo cuando pasaría del 13 ocupan los números de debajo (J R12 R13 es
+	// 11, 12 y 13).
+	if EsEscaleraValida(copia) {
+		menor := 13
+		for _, f := range copia {
+			if f.Numero != 0 {
+				menor = min(menor, f.Numero)
+			}
+		}
+		mayor := min(13, menor+len(copia)-1)
+		menor = mayor - len(copia) + 1
+		return (menor + mayor) * len(copia) / 2
+	}
+	return 0
+}
+
+// SePuedeAnadirFicha comprueba si una ficha puede ser añadida a una jugada existente
+// con el reglamento estándar.
+func SePuedeAnadirFicha(jugada []mazo.Pieza, ficha mazo.Pieza) bool {
+	return ReglasGrupos{}.SePuedeAnadirFicha(jugada, ficha)
+}
+
+// SePuedeAnadirFicha es como la función SePuedeAnadirFicha, con las variantes de r.
+func (r ReglasGrupos) SePuedeAnadirFicha(jugada []mazo.Pieza, ficha mazo.Pieza) bool {
+	// Importante: Creamos una copia para no modificar la jugada original en la mesa.
+	// Primero creamos un slice con capacidad suficiente.
+	//jugadaTemporal := make([]Pieza, len(jugada), len(jugada)+1)
+	jugadaTemporal := make([]mazo.Pieza, len(jugada)+1)
+	copy(jugadaTemporal, jugada)
+	//jugadaTemporal = append(jugadaTemporal, ficha)
+	jugadaTemporal[len(jugada)] = ficha
+	if !r.SePuedeAmpliarGrupo(jugada) && !EsEscaleraValida(append([]mazo.Pieza(nil), jugadaTemporal...)) {
+		return false
+	}
+	return r.EsJugadaValida(jugadaTemporal)
+}
+
+// CalcularPuntosMano calcula los puntos totales de las fichas en la mano de un jugador.
+// Comodines valen 30 puntos, otras fichas valen su número.
+func CalcularPuntosMano(mano []mazo.Pieza) int {
+	total := 0
+	for _, p := range mano {
+		if p.Numero == 0 {
+			total += 30
+		} else {
+			total += p.Numero
+		}
+	}
+	return total
+}
+
+// OrdenarJugada deja una jugada en su orden canónico: por número ascendente y luego por color.
+// En las escaleras cada comodín ocupa la posición del número que representa; en los tríos
+// y cuartetas los comodines van al final.
+func OrdenarJugada(jugada []mazo.Pieza) {
+	fichasNormales := make([]mazo.Pieza, 0, len(jugada))
+	comodines := make([]mazo.Pieza, 0)
+	for _, ficha := range jugada {
+		if ficha.Numero == 0 {
+			comodines = append(comodines, ficha)
+		} else {
+			fichasNormales = append(fichasNormales, ficha)
+		}
+	}
+	sort.Slice(fichasNormales, func(i, j int) bool {
+		if fichasNormales[i].Numero != fichasNormales[j].Numero {
+			return fichasNormales[i].Numero < fichasNormales[j].Numero
+		}
+		return fichasNormales[i].Color < fichasNormales[j].Color
+	})
+	ordenada := make([]mazo.Pieza, 0, len(jugada))
+	esEscalera := len(fichasNormales) > 1 && fichasNormales[0].Numero != fichasNormales[1].Numero
+	if !esEscalera || !EsEscaleraValida(append([]mazo.Pieza(nil), jugada...)) {
+		ordenada = append(append(ordenada, fichasNormales...), comodines...)
+		copy(jugada, ordenada)
+		return
+	}
+	// Rellenamos los huecos de la escalera con comodines.
+	for i, ficha := range fichasNormales {
+		if i > 0 {
+			for n := fichasNormales[i-1].Numero + 1; n < ficha.Numero; n++ {
+				ordenada = append(ordenada, comodines[0])
+				comodines = comodines[1:]
+			}
+		}
+		ordenada = append(ordenada, ficha)
+	}
+	// Los comodines sobrantes alargan la escalera por arriba y, si llega al 13, por abajo.
+	alto := fichasNormales[len(fichasNormales)-1].Numero
+	for len(comodines) > 0 && alto < 13 {
+		ordenada = append(ordenada, comodines[0])
+		comodines = comodines[1:]
+		alto++
+	}
+	copy(jugada, comodines)
+	copy(jugada[len(comodines):], ordenada)
+}
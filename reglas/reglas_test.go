@@ -0,0 +1,285 @@
+package reglas
+
+import (
+	"reflect"
+	"testing"
+
+	"com.github/hapkiduki/rummikub/mazo"
+)
+
+func TestEsTrioValido(t *testing.T) {
+	// Implementamos Table Driven Tests
+	casosDePrueba := []struct {
+		nombre   string       // Nombre descriptivo del caso de prueba
+		fichas   []mazo.Pieza // El input para nuestra función
+		esperado bool         // el resultado que esperamos obtener
+	}{
+		{
+			nombre: "Trio valido de 3 fichas con comodin",
+			fichas: []mazo.Pieza{
+				{Color: mazo.Rojo, Numero: 7},
+				{Color: mazo.Azul, Numero: 7},
+				{Color: -1, Numero: 0}, // Comodín
+			},
+			esperado: true,
+		},
+		{
+			nombre: "Trio valido de 3 fichas",
+			fichas: []mazo.Pieza{
+				{Color: mazo.Rojo, Numero: 7},
+				{Color: mazo.Azul, Numero: 7},
+				{Color: mazo.Negro, Numero: 7},
+			},
+			esperado: true,
+		},
+		{
+			nombre: "Cuarteto válido de 4 fichas",
+			fichas: []mazo.Pieza{
+				{Color: mazo.Rojo, Numero: 10},
+				{Color: mazo.Azul, Numero: 10},
+				{Color: mazo.Amarillo, Numero: 10},
+				{Color: mazo.Negro, Numero: 10},
+			},
+			esperado: true,
+		},
+		{
+			nombre: "Inválido por menos de 3 fichas",
+			fichas: []mazo.Pieza{
+				{Color: mazo.Rojo, Numero: 5},
+				{Color: mazo.Azul, Numero: 5},
+			},
+			esperado: false,
+		},
+		{
+			nombre: "Inválido por números diferentes",
+			fichas: []mazo.Pieza{
+				{Color: mazo.Rojo, Numero: 8},
+				{Color: mazo.Azul, Numero: 7},
+				{Color: mazo.Negro, Numero: 8},
+			},
+			esperado: false,
+		},
+		{
+			nombre: "Inválido por color repetido",
+			fichas: []mazo.Pieza{
+				{Color: mazo.Rojo, Numero: 12},
+				{Color: mazo.Azul, Numero: 12},
+				{Color: mazo.Rojo, Numero: 12},
+			},
+			esperado: false,
+		},
+	}
+
+	for _, tc := range casosDePrueba {
+		// t.Run() crea un sub-test, lo que nos da reportes más limpios.
+		t.Run(tc.nombre, func(t *testing.T) {
+			resultado := EsTrioValido(tc.fichas)
+			if resultado != tc.esperado {
+				// Reporta un error pero continua con los test cases
+				t.Errorf("Se esperaba %v, pero se obtuvo %v", tc.esperado, resultado)
+			}
+		})
+	}
+}
+
+func TestReglasGrupos(t *testing.T) {
+	comodin := mazo.Pieza{Color: -1, Numero: 0}
+	sieteRojo, sieteAzul, sieteNegro := mazo.Pieza{Color: mazo.Rojo, Numero: 7}, mazo.Pieza{Color: mazo.Azul, Numero: 7}, mazo.Pieza{Color: mazo.Negro, Numero: 7}
+	casosDePrueba := []struct {
+		nombre   string
+		reglas   ReglasGrupos
+		fichas   []mazo.Pieza
+		esperado bool
+	}{
+		{nombre: "Estándar: solo comodines no es válido", fichas: []mazo.Pieza{comodin, comodin, comodin}, esperado: false},
+		{nombre: "Variante: solo comodines", reglas: ReglasGrupos{PermitirSoloComodines: true}, fichas: []mazo.Pieza{comodin, comodin, comodin}, esperado: true},
+		{nombre: "Estándar: una ficha con dos comodines", fichas: []mazo.Pieza{sieteRojo, comodin, comodin}, esperado: true},
+		{nombre: "Variante: más comodines que fichas", reglas: ReglasGrupos{LimitarComodines: true}, fichas: []mazo.Pieza{sieteRojo, comodin, comodin}, esperado: false},
+		{nombre: "Variante: tantos comodines como fichas", reglas: ReglasGrupos{LimitarComodines: true}, fichas: []mazo.Pieza{sieteRojo, sieteAzul, comodin, comodin}, esperado: true},
+		{nombre: "Variante: una ficha con tres comodines", reglas: ReglasGrupos{PermitirSoloComodines: true, LimitarComodines: true}, fichas: []mazo.Pieza{sieteRojo, comodin, comodin, comodin}, esperado: false},
+		{nombre: "Las variantes no cambian el color repetido", reglas: ReglasGrupos{PermitirSoloComodines: true}, fichas: []mazo.Pieza{sieteRojo, sieteRojo, comodin}, esperado: false},
+	}
+
+	for _, tc := range casosDePrueba {
+		t.Run(tc.nombre, func(t *testing.T) {
+			if resultado := tc.reglas.EsTrioValido(tc.fichas); resultado != tc.esperado {
+				t.Errorf("Se esperaba %v, pero se obtuvo %v", tc.esperado, resultado)
+			}
+		})
+	}
+
+	// Ampliar un trío con dos comodines depende de CerrarConDosComodines.
+	ampliaciones := []struct {
+		nombre   string
+		reglas   ReglasGrupos
+		jugada   []mazo.Pieza
+		esperado bool
+	}{
+		{nombre: "Estándar: trío con dos comodines ampliable", jugada: []mazo.Pieza{sieteRojo, comodin, comodin}, esperado: true},
+		{nombre: "Variante: trío con dos comodines cerrado", reglas: ReglasGrupos{CerrarConDosComodines: true}, jugada: []mazo.Pieza{sieteRojo, comodin, comodin}, esperado: false},
+		{nombre: "Variante: trío con un comodín ampliable", reglas: ReglasGrupos{CerrarConDosComodines: true}, jugada: []mazo.Pieza{sieteRojo, sieteNegro, comodin}, esperado: true},
+	}
+	for _, tc := range ampliaciones {
+		t.Run(tc.nombre, func(t *testing.T) {
+			if resultado := tc.reglas.SePuedeAmpliarGrupo(tc.jugada); resultado != tc.esperado {
+				t.Errorf("Se esperaba %v, pero se obtuvo %v", tc.esperado, resultado)
+			}
+		})
+	}
+}
+
+func TestParsearReglasGrupos(t *testing.T) {
+	reglas, err := ParsearGrupos("solo-comodines, cerrar-dos-comodines")
+	if err != nil || reglas != (ReglasGrupos{PermitirSoloComodines: true, CerrarConDosComodines: true}) {
+		t.Errorf("Se esperaban dos variantes activas, pero fue %+v (%v)", reglas, err)
+	}
+	if _, err := ParsearGrupos("todo-vale"); err == nil {
+		t.Errorf("Se esperaba un error con una variante desconocida")
+	}
+}
+
+func TestEsEscaleraValida(t *testing.T) {
+	casosDePrueba := []struct {
+		nombre   string
+		fichas   []mazo.Pieza
+		esperado bool
+	}{
+		{
+			nombre: "Escalera válida simple",
+			fichas: []mazo.Pieza{
+				{Color: mazo.Rojo, Numero: 7},
+				{Color: mazo.Rojo, Numero: 8},
+				{Color: mazo.Rojo, Numero: 9},
+			},
+			esperado: true,
+		},
+		{
+			nombre: "Escalera válida larga y desordenada",
+			fichas: []mazo.Pieza{
+				{Color: mazo.Azul, Numero: 4}, // Desordenada a propósito
+				{Color: mazo.Azul, Numero: 2},
+				{Color: mazo.Azul, Numero: 1},
+				{Color: mazo.Azul, Numero: 3},
+			},
+			esperado: true,
+		},
+		{
+			nombre:   "Inválido por menos de 3 fichas",
+			fichas:   []mazo.Pieza{{Color: mazo.Negro, Numero: 1}, {Color: mazo.Negro, Numero: 2}},
+			esperado: false,
+		},
+		{
+			nombre: "Inválido por colores diferentes",
+			fichas: []mazo.Pieza{
+				{Color: mazo.Rojo, Numero: 5},
+				{Color: mazo.Azul, Numero: 6}, // Color incorrecto
+				{Color: mazo.Rojo, Numero: 7},
+			},
+			esperado: false,
+		},
+		{
+			nombre: "Inválido por número no consecutivo (hueco)",
+			fichas: []mazo.Pieza{
+				{Color: mazo.Amarillo, Numero: 10},
+				{Color: mazo.Amarillo, Numero: 11},
+				{Color: mazo.Amarillo, Numero: 13}, // Falta el 12
+			},
+			esperado: false,
+		},
+		{
+			nombre: "Inválido por número repetido",
+			fichas: []mazo.Pieza{
+				{Color: mazo.Negro, Numero: 3},
+				{Color: mazo.Negro, Numero: 4},
+				{Color: mazo.Negro, Numero: 4}, // Número repetido
+				{Color: mazo.Negro, Numero: 5},
+			},
+			esperado: false,
+		},
+	}
+
+	for _, tc := range casosDePrueba {
+		t.Run(tc.nombre, func(t *testing.T) {
+			// Importante: Hacemos una copia de las fichas para no modificar el caso de prueba original al ordenar.
+			fichasCopia := make([]mazo.Pieza, len(tc.fichas))
+			copy(fichasCopia, tc.fichas)
+
+			resultado := EsEscaleraValida(fichasCopia)
+			if resultado != tc.esperado {
+				t.Errorf("Resultado fue %t, pero se esperaba %t", resultado, tc.esperado)
+			}
+		})
+	}
+}
+
+func TestCalcularValorJugada(t *testing.T) {
+	casosDePrueba := []struct {
+		nombre   string
+		jugada   string
+		esperado int
+	}{
+		{nombre: "Trío con comodín", jugada: "R7 B7 J", esperado: 21},
+		{nombre: "Escalera sin comodines", jugada: "R10 R11 R12", esperado: 33},
+		{nombre: "Comodín en un hueco", jugada: "R5 J R7", esperado: 18},
+		{nombre: "Comodín al final", jugada: "R5 R6 J", esperado: 18},
+		{nombre: "Comodín debajo de una escalera que acaba en 13", jugada: "J R12 R13", esperado: 36},
+		{nombre: "Dos comodines debajo de una escalera que acaba en 13", jugada: "J J R12 R13", esperado: 46},
+		{nombre: "Jugada inválida", jugada: "R5 B6 K7", esperado: 0},
+	}
+
+	for _, tc := range casosDePrueba {
+		t.Run(tc.nombre, func(t *testing.T) {
+			jugada, err := mazo.ParsearFichas(tc.jugada)
+			if err != nil {
+				t.Fatalf("Notación inválida en el caso: %v", err)
+			}
+			if valor := CalcularValorJugada(jugada); valor != tc.esperado {
+				t.Errorf("Se esperaba que %s valiera %d, pero vale %d", tc.jugada, tc.esperado, valor)
+			}
+		})
+	}
+}
+
+func TestOrdenarJugada(t *testing.T) {
+	comodin := mazo.Pieza{Color: -1, Numero: 0}
+	casosDePrueba := []struct {
+		nombre   string
+		jugada   []mazo.Pieza
+		esperado []mazo.Pieza
+	}{
+		{
+			nombre:   "Escalera desordenada",
+			jugada:   []mazo.Pieza{{Color: mazo.Rojo, Numero: 9}, {Color: mazo.Rojo, Numero: 7}, {Color: mazo.Rojo, Numero: 8}},
+			esperado: []mazo.Pieza{{Color: mazo.Rojo, Numero: 7}, {Color: mazo.Rojo, Numero: 8}, {Color: mazo.Rojo, Numero: 9}},
+		},
+		{
+			nombre:   "Comodín en el hueco de la escalera",
+			jugada:   []mazo.Pieza{comodin, {Color: mazo.Azul, Numero: 5}, {Color: mazo.Azul, Numero: 3}},
+			esperado: []mazo.Pieza{{Color: mazo.Azul, Numero: 3}, comodin, {Color: mazo.Azul, Numero: 5}},
+		},
+		{
+			nombre:   "Comodín sobrante al final de la escalera",
+			jugada:   []mazo.Pieza{comodin, {Color: mazo.Negro, Numero: 4}, {Color: mazo.Negro, Numero: 3}},
+			esperado: []mazo.Pieza{{Color: mazo.Negro, Numero: 3}, {Color: mazo.Negro, Numero: 4}, comodin},
+		},
+		{
+			nombre:   "Comodín delante de una escalera que termina en 13",
+			jugada:   []mazo.Pieza{{Color: mazo.Amarillo, Numero: 13}, comodin, {Color: mazo.Amarillo, Numero: 12}},
+			esperado: []mazo.Pieza{comodin, {Color: mazo.Amarillo, Numero: 12}, {Color: mazo.Amarillo, Numero: 13}},
+		},
+		{
+			nombre:   "Trío con comodín al final",
+			jugada:   []mazo.Pieza{comodin, {Color: mazo.Negro, Numero: 7}, {Color: mazo.Rojo, Numero: 7}},
+			esperado: []mazo.Pieza{{Color: mazo.Rojo, Numero: 7}, {Color: mazo.Negro, Numero: 7}, comodin},
+		},
+	}
+
+	for _, tc := range casosDePrueba {
+		t.Run(tc.nombre, func(t *testing.T) {
+			OrdenarJugada(tc.jugada)
+			if !reflect.DeepEqual(tc.jugada, tc.esperado) {
+				t.Errorf("Se esperaba %v, pero se obtuvo %v", tc.esperado, tc.jugada)
+			}
+		})
+	}
+}
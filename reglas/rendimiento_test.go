@@ -0,0 +1,170 @@
+package reglas
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	"com.github/hapkiduki/rummikub/mazo"
+)
+
+// --- BENCHMARKS DE VALIDACIÓN Y BÚSQUEDA ---
+//
+// Las manos salen de barajar el mazo con semillas fijas, para que los resultados se
+// puedan comparar entre versiones:
+//
+//	go test ./reglas -run '^$' -bench . -benchmem
+
+// manosDePrueba reparte n manos de tam fichas, cada una de un mazo barajado con su
+// propia semilla.
+func manosDePrueba(n, tam int) [][]mazo.Pieza {
+	manos := make([][]mazo.Pieza, n)
+	for i := range manos {
+		fichas := mazo.Completo()
+		rand.New(rand.NewSource(int64(i+1))).Shuffle(len(fichas), func(a, b int) { fichas[a], fichas[b] = fichas[b], fichas[a] })
+		manos[i] = fichas[:tam]
+	}
+	return manos
+}
+
+// mesaLlena es una mesa de final de partida: muchas jugadas válidas, algunas largas y
+// con los dos comodines, sin más de dos copias de ninguna ficha.
+var mesaLlena = []string{
+	"R1 R2 R3 R4 R5 R6 R7 R8 R9 R10 R11 R12 R13",
+	"B3 B4 B5 B6 B7",
+	"Y9 Y10 Y11 Y12 Y13",
+	"K1 K2 K3",
+	"K5 K6 J K8 K9",
+	"R7 B7 Y7 K7",
+	"R11 B11 K11",
+	"B1 Y1 K1",
+	"Y2 Y3 Y4 Y5 Y6 Y7 Y8",
+	"B9 B10 B11 B12",
+	"R3 B3 K3",
+	"K10 K11 K12 K13",
+	"R5 Y5 K5",
+	"B13 Y13 K13",
+	"R8 R9 R10",
+	"J B4 B5",
+	"B6 K6 Y6",
+	"R12 B12 Y12 K12",
+}
+
+func jugadasDe(b *testing.B, notaciones []string) [][]mazo.Pieza {
+	b.Helper()
+	jugadas := make([][]mazo.Pieza, len(notaciones))
+	for i, notacion := range notaciones {
+		fichas, err := mazo.ParsearFichas(notacion)
+		if err != nil {
+			b.Fatalf("notación inválida en el benchmark: %v", err)
+		}
+		jugadas[i] = fichas
+	}
+	return jugadas
+}
+
+func BenchmarkEsJugadaValida(b *testing.B) {
+	casosDePrueba := []struct {
+		nombre  string
+		jugadas []string
+	}{
+		{nombre: "EscaleraCorta", jugadas: []string{"R4 R5 R6"}},
+		{nombre: "EscaleraCompleta", jugadas: []string{"B1 B2 B3 B4 B5 B6 B7 B8 B9 B10 B11 B12 B13"}},
+		{nombre: "EscaleraConComodines", jugadas: []string{"Y3 J Y5 Y6 J Y8"}},
+		{nombre: "Cuarteta", jugadas: []string{"R9 B9 Y9 K9"}},
+		{nombre: "Invalida", jugadas: []string{"R4 B5 Y6"}},
+		{nombre: "MesaLlena", jugadas: mesaLlena},
+	}
+	for _, tc := range casosDePrueba {
+		b.Run(tc.nombre, func(b *testing.B) {
+			jugadas := jugadasDe(b, tc.jugadas)
+			b.ReportAllocs()
+			for b.Loop() {
+				for _, jugada := range jugadas {
+					EsJugadaValida(jugada)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkBuscarJugadaEnMano(b *testing.B) {
+	casosDePrueba := []struct {
+		nombre string
+		fichas int
+	}{
+		{nombre: "ManoInicial", fichas: 14},
+		{nombre: "ManoDe25", fichas: 25},
+		{nombre: "ManoDe40", fichas: 40},
+	}
+	for _, tc := range casosDePrueba {
+		b.Run(tc.nombre, func(b *testing.B) {
+			manos := manosDePrueba(16, tc.fichas)
+			b.ReportAllocs()
+			i := 0
+			for b.Loop() {
+				<-BuscarJugadaEnMano(manos[i%len(manos)])
+				i++
+			}
+		})
+	}
+}
+
+func BenchmarkFindLongestRun(b *testing.B) {
+	// Cada grupo son las fichas de un color de la mano, ordenadas por número, que es
+	// lo que le pasa BuscarJugadaEnMano.
+	gruposDe := func(manos [][]mazo.Pieza) [][]mazo.Pieza {
+		var grupos [][]mazo.Pieza
+		for _, mano := range manos {
+			porColor := make(map[int][]mazo.Pieza)
+			for _, p := range mano {
+				porColor[p.Color] = append(porColor[p.Color], p)
+			}
+			for _, color := range []int{mazo.Rojo, mazo.Azul, mazo.Amarillo, mazo.Negro} {
+				grupo := porColor[color]
+				sort.Slice(grupo, func(i, j int) bool { return grupo[i].Numero < grupo[j].Numero })
+				grupos = append(grupos, grupo)
+			}
+		}
+		return grupos
+	}
+	casosDePrueba := []struct {
+		nombre string
+		grupos [][]mazo.Pieza
+	}{
+		{nombre: "ManoInicial", grupos: gruposDe(manosDePrueba(16, 14))},
+		{nombre: "ManoDe25", grupos: gruposDe(manosDePrueba(16, 25))},
+		{nombre: "ColorCompleto", grupos: gruposDe([][]mazo.Pieza{mazo.Completo()[:13]})[:1]},
+	}
+	for _, tc := range casosDePrueba {
+		b.Run(tc.nombre, func(b *testing.B) {
+			b.ReportAllocs()
+			i := 0
+			for b.Loop() {
+				findLongestRun(tc.grupos[i%len(tc.grupos)])
+				i++
+			}
+		})
+	}
+}
+
+func BenchmarkJugadasPosibles(b *testing.B) {
+	casosDePrueba := []struct {
+		nombre string
+		fichas int
+	}{
+		{nombre: "ManoInicial", fichas: 14},
+		{nombre: "ManoDe25", fichas: 25},
+	}
+	for _, tc := range casosDePrueba {
+		b.Run(tc.nombre, func(b *testing.B) {
+			manos := manosDePrueba(16, tc.fichas)
+			b.ReportAllocs()
+			i := 0
+			for b.Loop() {
+				JugadasPosibles(manos[i%len(manos)])
+				i++
+			}
+		})
+	}
+}
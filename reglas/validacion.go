@@ -0,0 +1,240 @@
+package reglas
+
+import (
+	"com.github/hapkiduki/rummikub/idioma"
+	"com.github/hapkiduki/rummikub/mazo"
+)
+
+// --- ERRORES DE VALIDACIÓN DE JUGADAS ---
+
+// MotivoInvalidez dice por qué unas fichas no forman una jugada válida.
+type MotivoInvalidez int
+
+const (
+	MotivoPocasFichas         MotivoInvalidez = iota + 1 // Menos de 3 fichas.
+	MotivoDemasiadasFichas                               // Un grupo de más de 4 fichas.
+	MotivoFichaInexistente                               // Un color o número que no existe en el juego.
+	MotivoSoloComodines                                  // Ninguna ficha normal.
+	MotivoNumerosDistintos                               // Un grupo con números diferentes.
+	MotivoColorRepetido                                  // Un grupo con dos fichas del mismo color.
+	MotivoDemasiadosComodines                            // Un grupo con más comodines que fichas (variante limitar-comodines).
+	MotivoColoresMezclados                               // Una escalera con fichas de varios colores.
+	MotivoNumeroRepetido                                 // Una escalera con dos fichas del mismo número.
+	MotivoHuecoSinComodines                              // Una escalera con más huecos que comodines.
+	MotivoFueraDeRango                                   // Una escalera que no cabe entre el 1 y el 13.
+)
+
+// ErrorJugada explica por qué una jugada no es válida y qué fichas causan el problema.
+type ErrorJugada struct {
+	Motivo MotivoInvalidez
+	Fichas []mazo.Pieza // Las fichas que sobran o chocan; todas si el problema es de la jugada entera.
+	// Cantidad acompaña a algunos motivos: el número de fichas en MotivoPocasFichas,
+	// MotivoDemasiadasFichas y MotivoFueraDeRango, y los comodines que faltan en
+	// MotivoHuecoSinComodines.
+	Cantidad int
+}
+
+// Error describe el problema en el idioma activo, nombrando las fichas que lo causan.
+func (e *ErrorJugada) Error() string {
+	switch e.Motivo {
+	case MotivoPocasFichas:
+		return idioma.Tf("una jugada necesita al menos 3 fichas y esta tiene %d", e.Cantidad)
+	case MotivoDemasiadasFichas:
+		return idioma.Tf("un trío o cuarteta tiene como mucho 4 fichas y este tiene %d", e.Cantidad)
+	case MotivoFichaInexistente:
+		return idioma.Tf("%v no es una ficha del juego", e.Fichas)
+	case MotivoSoloComodines:
+		return idioma.T("una jugada necesita al menos una ficha que no sea comodín")
+	case MotivoNumerosDistintos:
+		return idioma.Tf("en un trío todas las fichas llevan el mismo número, y %v no coincide", e.Fichas)
+	case MotivoColorRepetido:
+		return idioma.Tf("en un trío no se puede repetir color: %v", e.Fichas)
+	case MotivoDemasiadosComodines:
+		return idioma.T("este trío tiene más comodines que fichas")
+	case MotivoColoresMezclados:
+		return idioma.Tf("en una escalera todas las fichas son del mismo color, y %v no lo es", e.Fichas)
+	case MotivoNumeroRepetido:
+		return idioma.Tf("en una escalera no se puede repetir número: %v", e.Fichas)
+	case MotivoHuecoSinComodines:
+		return idioma.Tf("faltan %d comodín(es) para cubrir los huecos entre %v", e.Cantidad, e.Fichas)
+	case MotivoFueraDeRango:
+		return idioma.Tf("una escalera va del 1 al 13 y esta necesitaría %d fichas", e.Cantidad)
+	}
+	return idioma.T("las fichas no forman un trío o escalera válido")
+}
+
+// ValidarJugada comprueba si las fichas forman un trío, cuarteta o escalera. Si no,
+// devuelve un *ErrorJugada con el motivo que mejor explica lo que el jugador intentaba:
+// si las fichas se parecen más a un grupo (comparten número) o a una escalera (color).
+// Aplica el reglamento estándar; ReglasGrupos.ValidarJugada, las variantes de la partida.
+func ValidarJugada(fichas []mazo.Pieza) error {
+	return ReglasGrupos{}.ValidarJugada(fichas)
+}
+
+// ValidarJugada es como la función ValidarJugada, con las variantes de r para los grupos.
+func (r ReglasGrupos) ValidarJugada(fichas []mazo.Pieza) error {
+	if len(fichas) < 3 {
+		return &ErrorJugada{Motivo: MotivoPocasFichas, Fichas: fichas, Cantidad: len(fichas)}
+	}
+	normales, conjunto, inexistentes := contarFichas(fichas)
+	if len(inexistentes) > 0 {
+		return &ErrorJugada{Motivo: MotivoFichaInexistente, Fichas: inexistentes}
+	}
+	errGrupo := r.validarGrupo(normales, conjunto)
+	if errGrupo == nil {
+		return nil
+	}
+	errEscalera := validarEscalera(normales, conjunto)
+	if errEscalera == nil {
+		return nil
+	}
+	porNumero, porColor := 0, 0
+	for numero := 1; numero <= 13; numero++ {
+		n := 0
+		for _, color := range colores {
+			n += conjunto.Cuenta(mazo.Pieza{Color: color, Numero: numero})
+		}
+		porNumero = max(porNumero, n)
+	}
+	for _, color := range colores {
+		n := 0
+		for numero := 1; numero <= 13; numero++ {
+			n += conjunto.Cuenta(mazo.Pieza{Color: color, Numero: numero})
+		}
+		porColor = max(porColor, n)
+	}
+	if porNumero > porColor {
+		return errGrupo
+	}
+	return errEscalera
+}
+
+// validarGrupo comprueba un trío o cuarteta con las variantes de r. conjunto cuenta
+// todas las fichas, comodines incluidos; normales son las demás, para los errores.
+func (r ReglasGrupos) validarGrupo(normales []mazo.Pieza, conjunto mazo.ConjuntoFichas) *ErrorJugada {
+	comodines, total := conjunto.Comodines(), conjunto.Len()
+	if total < 3 {
+		return &ErrorJugada{Motivo: MotivoPocasFichas, Fichas: normales, Cantidad: total}
+	}
+	if total > 4 {
+		return &ErrorJugada{Motivo: MotivoDemasiadasFichas, Fichas: normales, Cantidad: total}
+	}
+	// Si solo hay comodines, no es una jugada válida por sí sola salvo que
+	// la variante lo permita (Rummikub estándar no lo hace).
+	if len(normales) == 0 {
+		if r.PermitirSoloComodines {
+			return nil
+		}
+		return &ErrorJugada{Motivo: MotivoSoloComodines}
+	}
+	numero := masRepetido(normales, func(p mazo.Pieza) int { return p.Numero })
+	if distintas := fichasSin(normales, func(p mazo.Pieza) bool { return p.Numero == numero }); len(distintas) > 0 {
+		return &ErrorJugada{Motivo: MotivoNumerosDistintos, Fichas: distintas}
+	}
+	// Todas tienen el mismo número: una ficha repetida en el conjunto repite color.
+	if repetidas := fichasSin(normales, func(p mazo.Pieza) bool { return conjunto.Cuenta(p) < 2 }); len(repetidas) > 0 {
+		return &ErrorJugada{Motivo: MotivoColorRepetido, Fichas: repetidas}
+	}
+	if r.LimitarComodines && comodines > len(normales) {
+		return &ErrorJugada{Motivo: MotivoDemasiadosComodines, Fichas: normales}
+	}
+	return nil
+}
+
+// validarEscalera comprueba una escalera: un solo color, números consecutivos y
+// comodines suficientes para los huecos, sin salirse del 1 al 13.
+func validarEscalera(normales []mazo.Pieza, conjunto mazo.ConjuntoFichas) *ErrorJugada {
+	comodines, total := conjunto.Comodines(), conjunto.Len()
+	if total < 3 {
+		return &ErrorJugada{Motivo: MotivoPocasFichas, Fichas: normales, Cantidad: total}
+	}
+	// Si no hay fichas normales, no se puede determinar el color o la secuencia.
+	if len(normales) == 0 {
+		return &ErrorJugada{Motivo: MotivoSoloComodines}
+	}
+	if total > 13 {
+		return &ErrorJugada{Motivo: MotivoFueraDeRango, Fichas: normales, Cantidad: total}
+	}
+	color := masRepetido(normales, func(p mazo.Pieza) int { return p.Color })
+	if otras := fichasSin(normales, func(p mazo.Pieza) bool { return p.Color == color }); len(otras) > 0 {
+		return &ErrorJugada{Motivo: MotivoColoresMezclados, Fichas: otras}
+	}
+	// Todas son del mismo color: una ficha repetida en el conjunto repite número.
+	if repetidas := fichasSin(normales, func(p mazo.Pieza) bool { return conjunto.Cuenta(p) < 2 }); len(repetidas) > 0 {
+		return &ErrorJugada{Motivo: MotivoNumeroRepetido, Fichas: repetidas}
+	}
+	var porNumero [14]mazo.Pieza
+	for _, ficha := range normales {
+		porNumero[ficha.Numero] = ficha
+	}
+	huecos, anterior := 0, 0
+	bordes := make([]mazo.Pieza, 0)
+	for numero := 1; numero <= 13; numero++ {
+		if !conjunto.Contiene(mazo.Pieza{Color: color, Numero: numero}) {
+			continue
+		}
+		if salto := numero - anterior - 1; anterior > 0 && salto > 0 {
+			huecos += salto
+			if len(bordes) == 0 || bordes[len(bordes)-1] != porNumero[anterior] {
+				bordes = append(bordes, porNumero[anterior])
+			}
+			bordes = append(bordes, porNumero[numero])
+		}
+		anterior = numero
+	}
+	if huecos > comodines {
+		return &ErrorJugada{Motivo: MotivoHuecoSinComodines, Fichas: bordes, Cantidad: huecos - comodines}
+	}
+	return nil
+}
+
+// contarFichas cuenta las fichas, comodines incluidos, en un ConjuntoFichas. Devuelve
+// también las fichas normales, para nombrarlas en los errores, y las que no existen en
+// el juego, que no caben en el conjunto.
+func contarFichas(fichas []mazo.Pieza) (normales []mazo.Pieza, conjunto mazo.ConjuntoFichas, inexistentes []mazo.Pieza) {
+	normales = make([]mazo.Pieza, 0, len(fichas))
+	for _, ficha := range fichas {
+		switch {
+		case ficha.Numero == 0:
+			conjunto.Agregar(ficha)
+		case ficha.Color < mazo.Rojo || ficha.Color > mazo.Negro || ficha.Numero < 1 || ficha.Numero > 13:
+			inexistentes = append(inexistentes, ficha)
+		default:
+			conjunto.Agregar(ficha)
+			normales = append(normales, ficha)
+		}
+	}
+	return normales, conjunto, inexistentes
+}
+
+// cuentasPor cuenta las fichas según la clave indicada (número o color).
+func cuentasPor(fichas []mazo.Pieza, clave func(mazo.Pieza) int) map[int]int {
+	cuentas := make(map[int]int)
+	for _, ficha := range fichas {
+		cuentas[clave(ficha)]++
+	}
+	return cuentas
+}
+
+// masRepetido devuelve la clave más frecuente; en caso de empate, la de la primera ficha.
+func masRepetido(fichas []mazo.Pieza, clave func(mazo.Pieza) int) int {
+	cuentas := cuentasPor(fichas, clave)
+	mejor := clave(fichas[0])
+	for _, ficha := range fichas {
+		if cuentas[clave(ficha)] > cuentas[mejor] {
+			mejor = clave(ficha)
+		}
+	}
+	return mejor
+}
+
+// fichasSin devuelve las fichas que no cumplen la condición.
+func fichasSin(fichas []mazo.Pieza, cumple func(mazo.Pieza) bool) []mazo.Pieza {
+	resto := make([]mazo.Pieza, 0)
+	for _, ficha := range fichas {
+		if !cumple(ficha) {
+			resto = append(resto, ficha)
+		}
+	}
+	return resto
+}
@@ -0,0 +1,59 @@
+package reglas
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"com.github/hapkiduki/rummikub/mazo"
+)
+
+func TestValidarJugada(t *testing.T) {
+	comodin := mazo.Pieza{Color: -1, Numero: 0}
+	r := func(n int) mazo.Pieza { return mazo.Pieza{Color: mazo.Rojo, Numero: n} }
+	a := func(n int) mazo.Pieza { return mazo.Pieza{Color: mazo.Azul, Numero: n} }
+	casosDePrueba := []struct {
+		nombre   string
+		fichas   []mazo.Pieza
+		motivo   MotivoInvalidez // 0 si la jugada es válida.
+		culpable []mazo.Pieza
+		cantidad int
+	}{
+		{nombre: "Escalera válida", fichas: []mazo.Pieza{r(3), r(4), comodin}},
+		{nombre: "Pocas fichas", fichas: []mazo.Pieza{r(3), r(4)}, motivo: MotivoPocasFichas, culpable: []mazo.Pieza{r(3), r(4)}, cantidad: 2},
+		{nombre: "Color repetido en un trío", fichas: []mazo.Pieza{r(7), a(7), r(7)}, motivo: MotivoColorRepetido, culpable: []mazo.Pieza{r(7), r(7)}},
+		{nombre: "Número distinto en un trío", fichas: []mazo.Pieza{r(7), a(7), mazo.Pieza{Color: mazo.Negro, Numero: 8}}, motivo: MotivoNumerosDistintos, culpable: []mazo.Pieza{{Color: mazo.Negro, Numero: 8}}},
+		{nombre: "Colores mezclados en una escalera", fichas: []mazo.Pieza{r(3), r(4), a(5)}, motivo: MotivoColoresMezclados, culpable: []mazo.Pieza{a(5)}},
+		{nombre: "Hueco sin comodines", fichas: []mazo.Pieza{r(3), r(4), r(8), comodin}, motivo: MotivoHuecoSinComodines, culpable: []mazo.Pieza{r(4), r(8)}, cantidad: 2},
+		{nombre: "Número repetido en una escalera", fichas: []mazo.Pieza{r(3), r(4), r(4), r(5)}, motivo: MotivoNumeroRepetido, culpable: []mazo.Pieza{r(4), r(4)}},
+		{nombre: "Grupo de cinco fichas", fichas: []mazo.Pieza{r(9), a(9), mazo.Pieza{Color: mazo.Negro, Numero: 9}, mazo.Pieza{Color: mazo.Amarillo, Numero: 9}, comodin}, motivo: MotivoDemasiadasFichas, cantidad: 5},
+		{nombre: "Ficha que no existe", fichas: []mazo.Pieza{r(12), r(13), r(14)}, motivo: MotivoFichaInexistente, culpable: []mazo.Pieza{r(14)}},
+		{nombre: "Escalera más larga que 13", fichas: append([]mazo.Pieza{r(1), r(2), r(3), r(4), r(5), r(6), r(7), r(8), r(9), r(10), r(11), r(12), r(13)}, comodin), motivo: MotivoFueraDeRango, cantidad: 14},
+		{nombre: "Solo comodines", fichas: []mazo.Pieza{comodin, comodin, comodin}, motivo: MotivoSoloComodines},
+	}
+
+	for _, tc := range casosDePrueba {
+		t.Run(tc.nombre, func(t *testing.T) {
+			err := ValidarJugada(tc.fichas)
+			if tc.motivo == 0 {
+				if err != nil {
+					t.Errorf("Se esperaba una jugada válida, pero fue: %v", err)
+				}
+				return
+			}
+			var errJugada *ErrorJugada
+			if !errors.As(err, &errJugada) {
+				t.Fatalf("Se esperaba un *ErrorJugada, pero fue %v", err)
+			}
+			if errJugada.Motivo != tc.motivo {
+				t.Errorf("Se esperaba el motivo %d, pero fue %d (%v)", tc.motivo, errJugada.Motivo, err)
+			}
+			if tc.culpable != nil && !reflect.DeepEqual(errJugada.Fichas, tc.culpable) {
+				t.Errorf("Se esperaban las fichas %v, pero fueron %v", tc.culpable, errJugada.Fichas)
+			}
+			if errJugada.Cantidad != tc.cantidad {
+				t.Errorf("Se esperaba la cantidad %d, pero fue %d", tc.cantidad, errJugada.Cantidad)
+			}
+		})
+	}
+}